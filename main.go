@@ -5,54 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"time"
 
+	"github.com/TuanKiri/weather-mcp-server/pkg/airquality"
+	"github.com/TuanKiri/weather-mcp-server/pkg/stats"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// WeatherData represents the structure of weather API response
-type WeatherData struct {
-	Location struct {
-		Name    string  `json:"name"`
-		Country string  `json:"country"`
-		Region  string  `json:"region"`
-		Lat     float64 `json:"lat"`
-		Lon     float64 `json:"lon"`
-	} `json:"location"`
-	Current struct {
-		TempC     float64 `json:"temp_c"`
-		TempF     float64 `json:"temp_f"`
-		Condition struct {
-			Text string `json:"text"`
-			Icon string `json:"icon"`
-		} `json:"condition"`
-		Humidity   int     `json:"humidity"`
-		WindKph    float64 `json:"wind_kph"`
-		WindMph    float64 `json:"wind_mph"`
-		WindDir    string  `json:"wind_dir"`
-		PressureMb float64 `json:"pressure_mb"`
-		FeelsLikeC float64 `json:"feelslike_c"`
-		FeelsLikeF float64 `json:"feelslike_f"`
-		UV         float64 `json:"uv"`
-	} `json:"current"`
-	Forecast struct {
-		Forecastday []struct {
-			Date string `json:"date"`
-			Day  struct {
-				MaxtempC  float64 `json:"maxtemp_c"`
-				MintempC  float64 `json:"mintemp_c"`
-				Condition struct {
-					Text string `json:"text"`
-					Icon string `json:"icon"`
-				} `json:"condition"`
-			} `json:"day"`
-		} `json:"forecastday"`
-	} `json:"forecast"`
-}
-
 func main() {
 	// Get API key from environment
 	apiKey := os.Getenv("WEATHER_API_KEY")
@@ -60,6 +23,8 @@ func main() {
 		log.Fatal("WEATHER_API_KEY environment variable is required")
 	}
 
+	wAPI := weatherapi.New(apiKey, 10*time.Second)
+
 	// Create a new MCP server
 	s := server.NewMCPServer(
 		"Mark3Labs Weather MCP Server",
@@ -88,7 +53,7 @@ func main() {
 		units, _ := request.OptionalString("units", "celsius")
 
 		// Fetch weather data
-		weatherData, err := fetchWeatherData(apiKey, city)
+		weatherData, err := wAPI.Current(ctx, city)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch weather data: %v", err)), nil
 		}
@@ -100,11 +65,11 @@ func main() {
 		if units == "fahrenheit" {
 			temp = weatherData.Current.TempF
 			tempUnit = "°F"
-			feelsLike = weatherData.Current.FeelsLikeF
+			feelsLike = weatherData.Current.FeelslikeF
 		} else {
 			temp = weatherData.Current.TempC
 			tempUnit = "°C"
-			feelsLike = weatherData.Current.FeelsLikeC
+			feelsLike = weatherData.Current.FeelslikeC
 		}
 
 		result := fmt.Sprintf(`🌤️ Current Weather for %s, %s:
@@ -122,7 +87,7 @@ func main() {
 • Country: %s
 • Region: %s
 • Wind Speed (mph): %.1f mph
-• Last Updated: %s`,
+• %s`,
 			weatherData.Location.Name,
 			weatherData.Location.Country,
 			temp,
@@ -140,7 +105,7 @@ func main() {
 			weatherData.Location.Country,
 			weatherData.Location.Region,
 			weatherData.Current.WindMph,
-			time.Now().Format("2006-01-02 15:04:05"),
+			lastUpdated(weatherData.Location, weatherData.Current, time.Now()),
 		)
 
 		return mcp.NewToolResultText(result), nil
@@ -173,33 +138,29 @@ func main() {
 		days, _ := request.OptionalInteger("days", 3)
 		units, _ := request.OptionalString("units", "celsius")
 
-		// Fetch weather data
-		weatherData, err := fetchWeatherData(apiKey, city)
+		// Fetch forecast data
+		weatherData, err := wAPI.Forecast(ctx, city, days)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch weather data: %v", err)), nil
 		}
 
 		// Format forecast
-		result := fmt.Sprintf("🌤️ Weather Forecast for %s, %s (%d days):\n\n", 
+		result := fmt.Sprintf("🌤️ Weather Forecast for %s, %s (%d days):\n\n",
 			weatherData.Location.Name, weatherData.Location.Country, days)
-		
-		for i, day := range weatherData.Forecast.Forecastday {
-			if i >= days {
-				break
-			}
-			
+
+		for _, day := range weatherData.Forecast.ForecastDay {
 			var maxTemp, minTemp float64
 			var tempUnit string
 			if units == "fahrenheit" {
-				maxTemp = day.Day.MaxtempC*9/5 + 32
-				minTemp = day.Day.MintempC*9/5 + 32
+				maxTemp = day.Day.MaxtempF
+				minTemp = day.Day.MintempF
 				tempUnit = "°F"
 			} else {
 				maxTemp = day.Day.MaxtempC
 				minTemp = day.Day.MintempC
 				tempUnit = "°C"
 			}
-			
+
 			result += fmt.Sprintf("📅 %s:\n   🌡️ High: %.1f%s\n   🌡️ Low: %.1f%s\n   ☁️ Condition: %s\n\n",
 				day.Date,
 				maxTemp,
@@ -228,23 +189,49 @@ func main() {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// For demo purposes, return a mock air quality response
-		// In a real implementation, you would call an air quality API
-		result := fmt.Sprintf(`🌬️ Air Quality for %s:
-
-📊 AQI: 45 (Good)
-🌫️ PM2.5: 12 μg/m³
-🌫️ PM10: 25 μg/m³
-☁️ Ozone: 35 ppb
-💨 Nitrogen Dioxide: 18 ppb
-🌡️ Carbon Monoxide: 0.8 ppm
-
-🏥 Health Impact: Good air quality. Enjoy outdoor activities.
-✅ Recommendations:
-   • Safe for outdoor activities
-   • Good for sensitive groups
-   • No health warnings`,
-			city,
+		// Fetch air quality data
+		weatherData, err := wAPI.Current(ctx, city, weatherapi.WithAirQuality())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch air quality data: %v", err)), nil
+		}
+
+		if weatherData.Current.AirQuality == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Air quality data is not available for %s", city)), nil
+		}
+
+		aq := weatherData.Current.AirQuality
+		assessment := airquality.Assess(*aq)
+
+		result := fmt.Sprintf(`🌬️ Air Quality for %s, %s:
+
+📊 AQI: %d (%s)
+🌫️ PM2.5: %.1f μg/m³
+🌫️ PM10: %.1f μg/m³
+☁️ Ozone: %.1f μg/m³
+💨 Nitrogen Dioxide: %.1f μg/m³
+🏭 Sulphur Dioxide: %.1f μg/m³
+🌡️ Carbon Monoxide: %.1f μg/m³
+
+🇺🇸 US EPA Index: %d
+🇬🇧 UK DEFRA Index: %d (%s)
+
+🏥 Health Impact: %s
+🔎 Dominant Pollutant: %s`,
+			weatherData.Location.Name,
+			weatherData.Location.Country,
+			assessment.Index,
+			assessment.Category,
+			aq.PM25,
+			aq.PM10,
+			aq.O3,
+			aq.NO2,
+			aq.SO2,
+			aq.CO,
+			aq.USEPAIndex,
+			aq.GBDEFRAIndex,
+			assessment.DEFRABand,
+			assessment.Category.Advice(),
+			assessment.Dominant,
 		)
 
 		return mcp.NewToolResultText(result), nil
@@ -265,78 +252,88 @@ func main() {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		// Mock weather alerts
-		result := fmt.Sprintf(`⚠️ Weather Alerts for %s:
+		// Fetch active alerts
+		alertsData, err := wAPI.Alerts(ctx, city)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch weather alerts: %v", err)), nil
+		}
 
-🔴 Severe Weather Warning:
-   • Type: Thunderstorm Warning
-   • Severity: Moderate
-   • Duration: 2 hours
-   • Description: Thunderstorms with heavy rain and lightning expected
+		alerts := alertsData.Alerts.Alert
+		if len(alerts) == 0 {
+			return mcp.NewToolResultText(fmt.Sprintf("✅ No active weather alerts for %s, %s",
+				alertsData.Location.Name, alertsData.Location.Country)), nil
+		}
 
-🟡 Weather Advisory:
-   • Type: Wind Advisory
-   • Severity: Minor
-   • Duration: 4 hours
-   • Description: Strong winds up to 25 mph expected
+		result := fmt.Sprintf("⚠️ Weather Alerts for %s, %s:\n",
+			alertsData.Location.Name, alertsData.Location.Country)
+
+		for _, alert := range alerts {
+			result += fmt.Sprintf("\n🔴 %s:\n   • Event: %s\n   • Severity: %s\n   • Urgency: %s\n   • Areas: %s\n   • Effective: %s\n   • Expires: %s\n",
+				alert.Headline,
+				alert.Event,
+				alert.Severity,
+				alert.Urgency,
+				alert.Areas,
+				alert.Effective,
+				alert.Expires,
+			)
 
-✅ Current Status: No immediate threats
-📱 Stay informed with local weather updates`,
-			city,
-		)
+			if alert.Instruction != "" {
+				result += fmt.Sprintf("   • Instruction: %s\n", alert.Instruction)
+			}
+		}
 
 		return mcp.NewToolResultText(result), nil
 	})
 
-	// Add resources for historical data
-	weatherHistoryResource := mcp.Resource{
-		URI:         "weather://history/{city}",
-		Name:        "Weather History",
-		Description: "Historical weather data for a city",
-		MIMEType:    "application/json",
-	}
+	// Add resource template for historical data
+	weatherHistoryResource := mcp.NewResourceTemplate(
+		"weather://history/{city}{?date,end_date}",
+		"Weather History",
+		mcp.WithTemplateDescription("Observed daily weather for a city. date and end_date are YYYY-MM-DD and default to the last 7 days"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
 
-	s.AddResource(weatherHistoryResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		// Extract city from URI
-		city := "Unknown"
-		if args := request.Params.Arguments; args != nil {
-			if cityArg, ok := args["city"].(string); ok {
-				city = cityArg
-			}
+	s.AddResourceTemplate(weatherHistoryResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		city := uriArgument(request.Params.Arguments, "city")
+		if city == "" {
+			return nil, fmt.Errorf("city is required")
 		}
 
-		// Mock historical data
-		historyData := map[string]interface{}{
-			"city": city,
-			"history": []map[string]interface{}{
-				{
-					"date":        time.Now().AddDate(0, 0, -1).Format("2006-01-02"),
-					"temperature": 22.5,
-					"condition":   "Sunny",
-					"humidity":    65,
-					"wind_speed":  15.2,
-					"pressure":    1013.2,
-				},
-				{
-					"date":        time.Now().AddDate(0, 0, -2).Format("2006-01-02"),
-					"temperature": 18.2,
-					"condition":   "Cloudy",
-					"humidity":    78,
-					"wind_speed":  12.8,
-					"pressure":    1008.5,
-				},
-				{
-					"date":        time.Now().AddDate(0, 0, -3).Format("2006-01-02"),
-					"temperature": 25.1,
-					"condition":   "Partly Cloudy",
-					"humidity":    58,
-					"wind_speed":  18.5,
-					"pressure":    1015.8,
-				},
-			},
+		date, endDate, err := historyRange(request.Params.Arguments)
+		if err != nil {
+			return nil, err
 		}
 
-		jsonData, _ := json.MarshalIndent(historyData, "", "  ")
+		historyData, err := wAPI.History(ctx, city, date, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch weather history: %w", err)
+		}
+
+		days := make([]map[string]interface{}, 0, len(historyData.Forecast.ForecastDay))
+		for _, day := range historyData.Forecast.ForecastDay {
+			days = append(days, map[string]interface{}{
+				"date":            day.Date,
+				"max_temperature": day.Day.MaxtempC,
+				"min_temperature": day.Day.MintempC,
+				"avg_temperature": day.Day.AvgtempC,
+				"total_precip_mm": day.Day.TotalprecipMm,
+				"avg_humidity":    day.Day.AvgHumidity,
+				"max_wind_kph":    day.Day.MaxwindKph,
+				"condition":       day.Day.Condition.Text,
+			})
+		}
+
+		jsonData, err := json.MarshalIndent(map[string]interface{}{
+			"city":     historyData.Location.Name,
+			"country":  historyData.Location.Country,
+			"date":     date.Format("2006-01-02"),
+			"end_date": endDate.Format("2006-01-02"),
+			"history":  days,
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
 
 		return []mcp.ResourceContents{
 			mcp.TextResourceContents{
@@ -347,43 +344,45 @@ func main() {
 		}, nil
 	})
 
-	// Add resource for weather statistics
-	weatherStatsResource := mcp.Resource{
-		URI:         "weather://stats/{city}",
-		Name:        "Weather Statistics",
-		Description: "Weather statistics and trends for a city",
-		MIMEType:    "application/json",
-	}
+	// Add resource template for weather statistics
+	weatherStatsResource := mcp.NewResourceTemplate(
+		"weather://stats/{city}{?date,end_date}",
+		"Weather Statistics",
+		mcp.WithTemplateDescription("Weather statistics and temperature trend computed from observed history. date and end_date are YYYY-MM-DD and default to the last 7 days"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
 
-	s.AddResource(weatherStatsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		// Extract city from URI
-		city := "Unknown"
-		if args := request.Params.Arguments; args != nil {
-			if cityArg, ok := args["city"].(string); ok {
-				city = cityArg
-			}
+	s.AddResourceTemplate(weatherStatsResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		city := uriArgument(request.Params.Arguments, "city")
+		if city == "" {
+			return nil, fmt.Errorf("city is required")
 		}
 
-		// Mock statistics data
-		statsData := map[string]interface{}{
-			"city": city,
-			"statistics": map[string]interface{}{
-				"average_temperature": 20.5,
-				"max_temperature":     32.1,
-				"min_temperature":     8.3,
-				"average_humidity":    68.2,
-				"rainy_days":          45,
-				"sunny_days":          120,
-				"windy_days":          23,
-			},
-			"trends": map[string]interface{}{
-				"temperature_trend": "increasing",
-				"humidity_trend":    "stable",
-				"rainfall_trend":    "decreasing",
-			},
+		date, endDate, err := historyRange(request.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		historyData, err := wAPI.History(ctx, city, date, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch weather history: %w", err)
 		}
 
-		jsonData, _ := json.MarshalIndent(statsData, "", "  ")
+		summary, err := stats.Compute(historyData.Forecast.ForecastDay)
+		if err != nil {
+			return nil, err
+		}
+
+		jsonData, err := json.MarshalIndent(map[string]interface{}{
+			"city":       historyData.Location.Name,
+			"country":    historyData.Location.Country,
+			"date":       date.Format("2006-01-02"),
+			"end_date":   endDate.Format("2006-01-02"),
+			"statistics": summary,
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
 
 		return []mcp.ResourceContents{
 			mcp.TextResourceContents{
@@ -399,11 +398,11 @@ func main() {
 	log.Println("📋 Available tools:")
 	log.Println("   • get_current_weather - Get current weather with detailed info")
 	log.Println("   • get_weather_forecast - Get multi-day weather forecasts")
-	log.Println("   • get_air_quality - Get air quality information")
+	log.Println("   • get_air_quality - Get measured air quality and health bands")
 	log.Println("   • get_weather_alerts - Get weather alerts and warnings")
 	log.Println("📁 Available resources:")
-	log.Println("   • weather://history/{city} - Historical weather data")
-	log.Println("   • weather://stats/{city} - Weather statistics and trends")
+	log.Println("   • weather://history/{city}{?date,end_date} - Historical weather data")
+	log.Println("   • weather://stats/{city}{?date,end_date} - Weather statistics and trends")
 	log.Println("🔧 Features:")
 	log.Println("   • Type-safe parameters with JSON Schema validation")
 	log.Println("   • Multiple temperature units (celsius/fahrenheit)")
@@ -416,23 +415,73 @@ func main() {
 	}
 }
 
-func fetchWeatherData(apiKey, city string) (*WeatherData, error) {
-	url := fmt.Sprintf("http://api.weatherapi.com/v1/forecast.json?key=%s&q=%s&days=7&aqi=no", apiKey, city)
+// uriArgument returns a URI template variable, which the server passes as a list of values.
+func uriArgument(args map[string]any, name string) string {
+	switch value := args[name].(type) {
+	case string:
+		return value
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	}
+
+	return ""
+}
+
+// lastUpdated tells when the weather was observed, how long ago, and the
+// time now at the location, all in the location's time zone rather than
+// the server's.
+func lastUpdated(location models.Location, current models.Current, now time.Time) string {
+	const layout = "2006-01-02 15:04 MST"
 
-	resp, err := http.Get(url)
+	zone, err := time.LoadLocation(location.TzID)
 	if err != nil {
-		return nil, err
+		zone = time.UTC
+	}
+
+	localTime := now.In(zone).Format(layout)
+
+	if current.LastUpdatedEpoch == 0 {
+		return "Local Time: " + localTime
+	}
+
+	observed := time.Unix(current.LastUpdatedEpoch, 0).In(zone)
+
+	age := "just now"
+	if minutes := int(now.Sub(observed) / time.Minute); minutes >= 60 {
+		age = fmt.Sprintf("%d h %d min ago", minutes/60, minutes%60)
+	} else if minutes >= 1 {
+		age = fmt.Sprintf("%d min ago", minutes)
+	}
+
+	return fmt.Sprintf("Last Updated: %s (%s)\n• Local Time: %s", observed.Format(layout), age, localTime)
+}
+
+// historyRange reads the date and end_date URI variables, defaulting to the last 7 days.
+func historyRange(args map[string]any) (time.Time, time.Time, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	date, endDate := today.AddDate(0, 0, -7), today.AddDate(0, 0, -1)
+
+	if value := uriArgument(args, "date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("date must be in YYYY-MM-DD format: %w", err)
+		}
+		date, endDate = parsed, parsed
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+	if value := uriArgument(args, "end_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("end_date must be in YYYY-MM-DD format: %w", err)
+		}
+		endDate = parsed
 	}
 
-	var weatherData WeatherData
-	if err := json.NewDecoder(resp.Body).Decode(&weatherData); err != nil {
-		return nil, err
+	if endDate.Before(date) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must not be before date")
 	}
 
-	return &weatherData, nil
-} 
\ No newline at end of file
+	return date, endDate, nil
+}
@@ -2,10 +2,15 @@ module mark3labs-weather-server
 
 go 1.24.5
 
-require github.com/mark3labs/mcp-go v0.35.0
+require (
+	github.com/TuanKiri/weather-mcp-server v0.0.0-00010101000000-000000000000
+	github.com/mark3labs/mcp-go v0.35.0
+)
 
 require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/spf13/cast v1.7.1 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 )
+
+replace github.com/TuanKiri/weather-mcp-server => ../weather-mcp-server
@@ -14,6 +14,9 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/condition"
+	"github.com/TuanKiri/weather-mcp-server/pkg/recommend"
 )
 
 // WeatherAPI response structures
@@ -23,9 +26,14 @@ type WeatherResponse struct {
 }
 
 type Location struct {
-	Name    string `json:"name"`
-	Country string `json:"country"`
-	Region  string `json:"region"`
+	Name           string  `json:"name"`
+	Country        string  `json:"country"`
+	Region         string  `json:"region"`
+	Lat            float64 `json:"lat"`
+	Lon            float64 `json:"lon"`
+	TzID           string  `json:"tz_id"`
+	Localtime      string  `json:"localtime"`
+	LocaltimeEpoch int64   `json:"localtime_epoch"`
 }
 
 type Current struct {
@@ -34,6 +42,7 @@ type Current struct {
 	Condition struct {
 		Text string `json:"text"`
 		Icon string `json:"icon"`
+		Code int64  `json:"code"`
 	} `json:"condition"`
 	Humidity   int     `json:"humidity"`
 	WindKph    float64 `json:"wind_kph"`
@@ -45,6 +54,9 @@ type Current struct {
 	Visibility float64 `json:"vis_km"`
 	UV         float64 `json:"uv"`
 	GustKph    float64 `json:"gust_kph"`
+	// LastUpdatedEpoch is when the weather was observed, in Unix seconds.
+	LastUpdatedEpoch int64  `json:"last_updated_epoch"`
+	LastUpdated      string `json:"last_updated"`
 }
 
 type WeatherAPI struct {
@@ -101,215 +113,17 @@ func (w *WeatherAPI) GetCurrentWeather(ctx context.Context, city string) (*Weath
 	return &weatherResp, nil
 }
 
-// getCityImage returns a weather-dependent location recommendation based on the city name
-func getCityImage(city string, condition string, tempC float64) string {
-	cityLower := strings.ToLower(city)
-
-	// Weather-dependent location recommendations
-	locationRecommendations := map[string]map[string]string{
-		"tokyo": {
-			"hot":   "🏯 Visit the air-conditioned Tokyo National Museum",
-			"warm":  "🌸 Stroll through Shinjuku Gyoen National Garden",
-			"cool":  "🗼 Climb Tokyo Tower for city views",
-			"cold":  "♨️ Relax in a traditional onsen (hot spring)",
-			"rainy": "🏛️ Explore the Imperial Palace East Gardens",
-			"sunny": "🎌 Walk the historic Meiji Shrine",
-		},
-		"london": {
-			"hot":   "🏛️ Cool off at the British Museum",
-			"warm":  "🌳 Enjoy Hyde Park and Kensington Gardens",
-			"cool":  "🎭 Visit the West End theatres",
-			"cold":  "☕ Warm up in a traditional English pub",
-			"rainy": "🏛️ Explore the Natural History Museum",
-			"sunny": "🌉 Walk across Tower Bridge",
-		},
-		"new york": {
-			"hot":   "🏛️ Visit the air-conditioned Metropolitan Museum",
-			"warm":  "🌳 Stroll through Central Park",
-			"cool":  "🗽 Take the ferry to Statue of Liberty",
-			"cold":  "☕ Warm up in a cozy Brooklyn café",
-			"rainy": "🎭 Catch a Broadway show",
-			"sunny": "🌆 Walk the High Line elevated park",
-		},
-		"paris": {
-			"hot":   "🏛️ Cool off at the Louvre Museum",
-			"warm":  "🌸 Stroll through Luxembourg Gardens",
-			"cool":  "🗼 Visit the Eiffel Tower",
-			"cold":  "☕ Warm up in a charming café",
-			"rainy": "🏛️ Explore the Musée d'Orsay",
-			"sunny": "🌉 Walk along the Seine River",
-		},
-		"sydney": {
-			"hot":   "🏛️ Visit the air-conditioned Art Gallery of NSW",
-			"warm":  "🏖️ Relax at Bondi Beach",
-			"cool":  "🎭 Visit the Sydney Opera House",
-			"cold":  "☕ Warm up in a harbor-side café",
-			"rainy": "🏛️ Explore the Australian Museum",
-			"sunny": "🌉 Walk across Sydney Harbour Bridge",
-		},
-		"duluth": {
-			"hot":   "🏛️ Visit the Great Lakes Aquarium",
-			"warm":  "🌊 Walk along Lake Superior",
-			"cool":  "🌉 Visit the Aerial Lift Bridge",
-			"cold":  "☕ Warm up in a cozy café",
-			"rainy": "🏛️ Explore the Duluth Art Institute",
-			"sunny": "🌳 Hike in Enger Park",
-		},
-		"mumbai": {
-			"hot":   "🏛️ Visit the air-conditioned National Museum",
-			"warm":  "🌊 Walk along Marine Drive",
-			"cool":  "🏛️ Visit the Gateway of India",
-			"cold":  "☕ Warm up in a local café",
-			"rainy": "🏛️ Explore the Chhatrapati Shivaji Museum",
-			"sunny": "🌳 Visit the Sanjay Gandhi National Park",
-		},
-		"beijing": {
-			"hot":   "🏛️ Visit the air-conditioned National Museum",
-			"warm":  "🏯 Walk through the Forbidden City",
-			"cool":  "🐉 Visit the Temple of Heaven",
-			"cold":  "☕ Warm up in a traditional tea house",
-			"rainy": "🏛️ Explore the Capital Museum",
-			"sunny": "🌉 Walk along the Great Wall",
-		},
-		"moscow": {
-			"hot":   "🏛️ Visit the air-conditioned Tretyakov Gallery",
-			"warm":  "🌳 Stroll through Gorky Park",
-			"cool":  "⛪ Visit Saint Basil's Cathedral",
-			"cold":  "☕ Warm up in a cozy café",
-			"rainy": "🏛️ Explore the Pushkin Museum",
-			"sunny": "🏰 Walk through Red Square",
-		},
-		"cairo": {
-			"hot":   "🏛️ Visit the air-conditioned Egyptian Museum",
-			"warm":  "🐪 Take a camel ride near the pyramids",
-			"cool":  "🏺 Visit the Great Pyramid of Giza",
-			"cold":  "☕ Warm up in a traditional café",
-			"rainy": "🏛️ Explore the Coptic Museum",
-			"sunny": "🌊 Take a Nile River cruise",
-		},
-	}
-
-	// Determine weather category
-	var weatherCategory string
-	switch {
-	case tempC >= 25:
-		weatherCategory = "hot"
-	case tempC >= 15:
-		weatherCategory = "warm"
-	case tempC >= 5:
-		weatherCategory = "cool"
-	default:
-		weatherCategory = "cold"
-	}
-
-	// Check for specific weather conditions
-	if strings.Contains(strings.ToLower(condition), "rain") {
-		weatherCategory = "rainy"
-	} else if strings.Contains(strings.ToLower(condition), "sunny") || strings.Contains(strings.ToLower(condition), "clear") {
-		weatherCategory = "sunny"
-	}
-
-	// Get recommendation for the city
-	if cityRecs, exists := locationRecommendations[cityLower]; exists {
-		if rec, exists := cityRecs[weatherCategory]; exists {
-			return rec
-		}
-		// Fallback to warm if specific category doesn't exist
-		if rec, exists := cityRecs["warm"]; exists {
-			return rec
-		}
-	}
-
-	// Default recommendations for other cities
-	defaultRecs := map[string]string{
-		"hot":   "🏛️ Visit a local museum to cool off",
-		"warm":  "🌳 Enjoy a local park or garden",
-		"cool":  "🏛️ Explore local attractions",
-		"cold":  "☕ Warm up in a cozy café",
-		"rainy": "🏛️ Visit indoor attractions",
-		"sunny": "🌳 Enjoy outdoor activities",
-	}
-
-	return defaultRecs[weatherCategory]
-}
-
-// getFunFact returns a weather-dependent fun fact about the city
-func getFunFact(city string, condition string, tempC float64) string {
-	cityLower := strings.ToLower(city)
-	conditionLower := strings.ToLower(condition)
-
-	// Weather-dependent fun facts
-	if strings.Contains(conditionLower, "rain") || strings.Contains(conditionLower, "drizzle") {
-		switch cityLower {
-		case "tokyo":
-			return "Perfect weather for visiting the beautiful cherry blossoms in Ueno Park! 🌸"
-		case "london":
-			return "Classic London weather! Great time to visit the British Museum or enjoy a cozy pub. ☔"
-		case "paris":
-			return "Rainy days in Paris are perfect for exploring the Louvre or enjoying café culture! ☕"
-		case "sydney":
-			return "Rain in Sydney means the Opera House looks even more dramatic! 🎭"
-		default:
-			return "Rainy days are perfect for indoor activities and cozy cafes! ☔"
-		}
-	} else if strings.Contains(conditionLower, "sunny") || strings.Contains(conditionLower, "clear") {
-		switch cityLower {
-		case "tokyo":
-			return "Perfect weather for visiting Tokyo Tower or taking a stroll in Yoyogi Park! 🗼"
-		case "london":
-			return "Sunny London! Great time to visit Hyde Park or take a Thames River cruise! ☀️"
-		case "new york":
-			return "Perfect weather for walking across the Brooklyn Bridge or visiting Central Park! 🌉"
-		case "paris":
-			return "Beautiful weather for climbing the Eiffel Tower or strolling along the Seine! 🗼"
-		case "sydney":
-			return "Great weather for visiting Bondi Beach or climbing the Sydney Harbour Bridge! 🏖️"
-		default:
-			return "Perfect weather for outdoor activities and sightseeing! ☀️"
-		}
-	} else if strings.Contains(conditionLower, "cloudy") || strings.Contains(conditionLower, "overcast") {
-		switch cityLower {
-		case "duluth":
-			return "Cloudy weather is perfect for exploring the beautiful Lake Superior shoreline! 🏞️"
-		case "london":
-			return "Classic London weather! Great for visiting museums or enjoying afternoon tea! ☁️"
-		case "sydney":
-			return "Cloudy weather is perfect for visiting the Royal Botanic Garden! 🌿"
-		default:
-			return "Cloudy weather is great for photography and exploring indoor attractions! ☁️"
-		}
-	} else if tempC > 30 {
-		return "Hot weather! Perfect time for ice cream and finding air-conditioned spots! 🍦"
-	} else if tempC < 10 {
-		return "Cold weather! Great time for hot drinks and cozy indoor activities! ☕"
-	}
-
-	// Default fun facts by city
-	switch cityLower {
-	case "tokyo":
-		return "Did you know Tokyo has the world's busiest pedestrian crossing at Shibuya? 🚶‍♂️"
-	case "london":
-		return "London has over 170 museums, many of them free to visit! 🏛️"
-	case "new york":
-		return "New York City has over 8 million people and 800 languages spoken! 🌆"
-	case "paris":
-		return "Paris is known as the 'City of Light' and has over 300 illuminated monuments! 💡"
-	case "sydney":
-		return "Sydney Harbour is home to over 600 species of fish! 🐟"
-	case "duluth":
-		return "Duluth is home to the world's largest freshwater lake, Lake Superior! 🏞️"
-	default:
-		return "Every city has its unique charm and hidden gems to discover! ✨"
-	}
-}
-
 // getWeatherAlert returns weather alerts based on conditions
-func getWeatherAlert(condition string, tempC float64, windKph float64, humidity int) string {
-	conditionLower := strings.ToLower(condition)
+func getWeatherAlert(code int64, tempC float64, windKph float64, humidity int) string {
+	class := condition.Classify(code)
 
-	if strings.Contains(conditionLower, "thunder") {
+	if class.Kind == condition.Thunder {
 		return "⚡ **WEATHER ALERT:** Thunderstorm detected - seek shelter immediately!"
-	} else if strings.Contains(conditionLower, "storm") {
+	} else if class.Wintry() && class.Intensity == condition.Heavy {
+		return "🌨️ **WEATHER ALERT:** Heavy snow or blizzard - avoid travel if you can!"
+	} else if class.Freezing && class.Kind != condition.Fog {
+		return "🧊 **ICE ALERT:** Freezing rain - watch out for icy roads and pavements!"
+	} else if class.Rainy() && class.Intensity == condition.Heavy {
 		return "🌪️ **WEATHER ALERT:** Storm conditions - avoid outdoor activities!"
 	} else if tempC > 35 {
 		return "🔥 **HEAT ALERT:** Extreme heat - stay hydrated and avoid sun exposure!"
@@ -337,51 +151,8 @@ func getAirQualityRecommendation(uv float64, visibility float64) string {
 	return "✅ **Good Conditions:** Safe for outdoor activities"
 }
 
-// getTravelRecommendations returns travel-specific advice
-func getTravelRecommendations(city string, condition string, tempC float64) string {
-	cityLower := strings.ToLower(city)
-	conditionLower := strings.ToLower(condition)
-
-	var recommendations []string
-
-	// General travel advice
-	if strings.Contains(conditionLower, "rain") {
-		recommendations = append(recommendations, "☔ **Travel Tip:** Pack waterproof gear and plan indoor activities")
-	} else if strings.Contains(conditionLower, "sunny") {
-		recommendations = append(recommendations, "🧴 **Travel Tip:** Bring sunscreen and stay hydrated")
-	} else if tempC > 30 {
-		recommendations = append(recommendations, "🏊 **Travel Tip:** Perfect weather for water activities")
-	} else if tempC < 10 {
-		recommendations = append(recommendations, "🧥 **Travel Tip:** Pack warm clothing and plan indoor visits")
-	}
-
-	// City-specific travel advice
-	switch cityLower {
-	case "tokyo":
-		recommendations = append(recommendations, "🚇 **Local Tip:** Use the efficient subway system to get around")
-		recommendations = append(recommendations, "🍜 **Food Tip:** Try local ramen shops for authentic cuisine")
-	case "london":
-		recommendations = append(recommendations, "🚇 **Local Tip:** Get an Oyster card for public transport")
-		recommendations = append(recommendations, "☕ **Food Tip:** Experience traditional afternoon tea")
-	case "new york":
-		recommendations = append(recommendations, "🚇 **Local Tip:** Use the subway - it's the fastest way around")
-		recommendations = append(recommendations, "🍕 **Food Tip:** Try authentic New York pizza")
-	case "paris":
-		recommendations = append(recommendations, "🚇 **Local Tip:** Use the Metro for easy navigation")
-		recommendations = append(recommendations, "🥐 **Food Tip:** Visit local bakeries for fresh pastries")
-	case "sydney":
-		recommendations = append(recommendations, "🚇 **Local Tip:** Use Opal card for public transport")
-		recommendations = append(recommendations, "🦘 **Local Tip:** Visit wildlife parks to see native animals")
-	case "duluth":
-		recommendations = append(recommendations, "🚗 **Local Tip:** Rent a car to explore the scenic shoreline")
-		recommendations = append(recommendations, "🏞️ **Local Tip:** Visit state parks for hiking and nature")
-	}
-
-	return strings.Join(recommendations, "\n")
-}
-
 // getWeatherScore returns a weather score out of 10
-func getWeatherScore(condition string, tempC float64, humidity int, windKph float64) (int, string) {
+func getWeatherScore(code int64, tempC float64, humidity int, windKph float64) (int, string) {
 	score := 5 // Base score
 
 	// Temperature scoring
@@ -394,15 +165,15 @@ func getWeatherScore(condition string, tempC float64, humidity int, windKph floa
 	}
 
 	// Condition scoring
-	conditionLower := strings.ToLower(condition)
-	if strings.Contains(conditionLower, "sunny") || strings.Contains(conditionLower, "clear") {
+	class := condition.Classify(code)
+	if class.Kind == condition.Clear {
 		score += 2
-	} else if strings.Contains(conditionLower, "partly cloudy") {
+	} else if class.Kind == condition.PartlyCloudy {
 		score += 1
-	} else if strings.Contains(conditionLower, "rain") {
-		score -= 1
-	} else if strings.Contains(conditionLower, "storm") {
+	} else if class.Kind == condition.Thunder || class.Intensity == condition.Heavy {
 		score -= 2
+	} else if class.Rainy() || class.Wintry() {
+		score -= 1
 	}
 
 	// Wind scoring
@@ -444,13 +215,55 @@ func getWeatherScore(condition string, tempC float64, humidity int, windKph floa
 	return score, description
 }
 
-func formatWeatherResponse(weather *WeatherResponse, city string) string {
-	cityImage := getCityImage(city, weather.Current.Condition.Text, weather.Current.TempC)
-	funFact := getFunFact(city, weather.Current.Condition.Text, weather.Current.TempC)
-	weatherAlert := getWeatherAlert(weather.Current.Condition.Text, weather.Current.TempC, weather.Current.WindKph, weather.Current.Humidity)
+// lastUpdated tells when the weather was observed, how long ago, and the
+// time now at the location, all in the location's time zone rather than
+// the server's.
+func lastUpdated(location Location, current Current, now time.Time) string {
+	const layout = "2006-01-02 15:04 MST"
+
+	zone, err := time.LoadLocation(location.TzID)
+	if err != nil {
+		zone = time.UTC
+	}
+
+	localTime := now.In(zone).Format(layout)
+
+	if current.LastUpdatedEpoch == 0 {
+		return "Local time: " + localTime
+	}
+
+	observed := time.Unix(current.LastUpdatedEpoch, 0).In(zone)
+
+	age := "just now"
+	if minutes := int(now.Sub(observed) / time.Minute); minutes >= 60 {
+		age = fmt.Sprintf("%d h %d min ago", minutes/60, minutes%60)
+	} else if minutes >= 1 {
+		age = fmt.Sprintf("%d min ago", minutes)
+	}
+
+	return fmt.Sprintf("Last updated: %s (%s), local time: %s", observed.Format(layout), age, localTime)
+}
+
+func formatWeatherResponse(weather *WeatherResponse, city string, recommendations *recommend.Catalog) string {
+	now := time.Now()
+
+	conditions := recommend.Weather{
+		Query:    city,
+		Name:     weather.Location.Name,
+		Lat:      weather.Location.Lat,
+		Time:     now,
+		Code:     weather.Current.Condition.Code,
+		TempC:    weather.Current.TempC,
+		Humidity: weather.Current.Humidity,
+		WindKph:  weather.Current.WindKph,
+	}
+
+	cityImage := recommendations.Place(conditions, recommend.DefaultLanguage)
+	funFact := recommendations.Fact(conditions, recommend.DefaultLanguage)
+	weatherAlert := getWeatherAlert(weather.Current.Condition.Code, weather.Current.TempC, weather.Current.WindKph, weather.Current.Humidity)
 	airQuality := getAirQualityRecommendation(weather.Current.UV, weather.Current.Visibility)
-	travelTips := getTravelRecommendations(city, weather.Current.Condition.Text, weather.Current.TempC)
-	weatherScore, scoreDescription := getWeatherScore(weather.Current.Condition.Text, weather.Current.TempC, weather.Current.Humidity, weather.Current.WindKph)
+	travelTips := strings.Join(recommendations.Travel(conditions, recommend.DefaultLanguage), "\n")
+	weatherScore, scoreDescription := getWeatherScore(weather.Current.Condition.Code, weather.Current.TempC, weather.Current.Humidity, weather.Current.WindKph)
 
 	// Build the enhanced response
 	response := fmt.Sprintf(`🌤️ **Weather for %s, %s** 🌤️
@@ -482,10 +295,9 @@ func formatWeatherResponse(weather *WeatherResponse, city string) string {
 **🎯 Travel Recommendations:**
 %s
 
-*Last updated: Current conditions*`,
+*%s*`,
 		weather.Location.Name,
 		weather.Location.Country,
-		weather.Location.Name,
 		cityImage,
 		weather.Current.TempC,
 		weather.Current.TempF,
@@ -507,6 +319,7 @@ func formatWeatherResponse(weather *WeatherResponse, city string) string {
 		weatherAlert,
 		airQuality,
 		travelTips,
+		lastUpdated(weather.Location, weather.Current, now),
 	)
 
 	return response
@@ -522,6 +335,17 @@ func main() {
 	// Initialize weather API client
 	weatherAPI := NewWeatherAPI(apiKey)
 
+	// Load recommendations, extended with our own cities if configured
+	recommendations := recommend.Default()
+	if path := os.Getenv("RECOMMENDATIONS_PATH"); path != "" {
+		catalog, err := recommend.Load(path)
+		if err != nil {
+			log.Fatalf("Failed to load recommendations: %v", err)
+		}
+
+		recommendations = catalog
+	}
+
 	// Create a new MCP server
 	s := server.NewMCPServer(
 		"Mark3Labs Weather MCP Server",
@@ -551,7 +375,7 @@ func main() {
 		}
 
 		// Format the response with enhanced features
-		result := formatWeatherResponse(weather, city)
+		result := formatWeatherResponse(weather, city, recommendations)
 		return mcp.NewToolResultText(result), nil
 	})
 
@@ -4,19 +4,49 @@ import (
 	"flag"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
+	// Embeds the time zone database for the local sun and moon times of the
+	// astronomy tool.
+	_ "time/tzdata"
 
 	"github.com/TuanKiri/weather-mcp-server/internal/server"
 )
 
 func main() {
 	addr := flag.String("address", "", "The host and port to start the sse server")
+	provider := flag.String("provider", server.ProviderWeatherAPI, "The weather provider: weatherapi, openmeteo or nws, or a comma-separated list in priority order")
+	consensus := flag.Bool("consensus", false, "Merge the current weather from every listed provider instead of failing over")
+	currentTTL := flag.Duration("cache-current-ttl", 10*time.Minute, "How long current weather is cached, 0 disables caching")
+	forecastTTL := flag.Duration("cache-forecast-ttl", time.Hour, "How long forecasts are cached, 0 disables caching")
+	alertsTTL := flag.Duration("cache-alerts-ttl", 5*time.Minute, "How long weather alerts are cached, 0 disables caching")
+	cachePath := flag.String("cache-path", "", "A BoltDB file that keeps cached responses across restarts")
+	rateLimit := flag.Float64("rate-limit", 5, "WeatherAPI requests per second, 0 disables pacing")
+	burst := flag.Int("rate-burst", 10, "WeatherAPI requests allowed in a burst")
+	dailyQuota := flag.Int64("daily-quota", 0, "WeatherAPI calls allowed per UTC day, 0 is unlimited")
+	monthlyQuota := flag.Int64("monthly-quota", 0, "WeatherAPI calls allowed per UTC month, 0 is unlimited")
+	quotaReserve := flag.Float64("quota-reserve", 0.05, "Fraction of each quota kept back; below it only cached data is served")
+	quotaPath := flag.String("quota-path", defaultQuotaPath(), "A JSON file that keeps the call counts across restarts, empty keeps them in memory only")
+	recommendations := flag.String("recommendations", "", "A JSON catalog of cities and rules that extends the built-in recommendations")
 	flag.Parse()
 
 	cfg := &server.Config{
-		ListenAddr:        *addr,
-		WeatherAPIKey:     os.Getenv("WEATHER_API_KEY"),
-		WeatherAPITimeout: 1 * time.Second,
+		ListenAddr:          *addr,
+		WeatherProvider:     *provider,
+		WeatherConsensus:    *consensus,
+		WeatherAPIKey:       os.Getenv("WEATHER_API_KEY"),
+		WeatherAPITimeout:   1 * time.Second,
+		CurrentCacheTTL:     *currentTTL,
+		ForecastCacheTTL:    *forecastTTL,
+		AlertsCacheTTL:      *alertsTTL,
+		CachePath:           *cachePath,
+		WeatherAPIRateLimit: *rateLimit,
+		WeatherAPIBurst:     *burst,
+		DailyQuota:          *dailyQuota,
+		MonthlyQuota:        *monthlyQuota,
+		QuotaReserve:        *quotaReserve,
+		QuotaPath:           *quotaPath,
+		RecommendationsPath: *recommendations,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -27,3 +57,14 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// defaultQuotaPath keeps the call counts in the user's cache directory, or
+// in memory only if there is none.
+func defaultQuotaPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "weather-mcp-server", "quota.json")
+}
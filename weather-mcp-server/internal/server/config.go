@@ -2,19 +2,97 @@ package server
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
+const (
+	ProviderWeatherAPI = "weatherapi"
+	ProviderOpenMeteo  = "openmeteo"
+	ProviderNWS        = "nws"
+)
+
 type Config struct {
-	ListenAddr        string
+	ListenAddr string
+	// WeatherProvider is a provider name or a comma-separated list of
+	// names in priority order, which are combined into a composite provider.
+	WeatherProvider string
+	// WeatherConsensus merges the current weather from every listed
+	// provider instead of failing over between them.
+	WeatherConsensus  bool
 	WeatherAPIKey     string
 	WeatherAPITimeout time.Duration
+	// CurrentCacheTTL, ForecastCacheTTL and AlertsCacheTTL set how long
+	// responses are cached. Zero disables caching for that endpoint.
+	CurrentCacheTTL  time.Duration
+	ForecastCacheTTL time.Duration
+	AlertsCacheTTL   time.Duration
+	// CachePath is a BoltDB file that keeps cached responses across
+	// restarts. Empty keeps the cache in memory only.
+	CachePath string
+	// WeatherAPIRateLimit paces WeatherAPI requests per second, allowing
+	// bursts of WeatherAPIBurst. Zero disables pacing.
+	WeatherAPIRateLimit float64
+	WeatherAPIBurst     int
+	// DailyQuota and MonthlyQuota cap WeatherAPI calls, zero is unlimited.
+	// Once the calls left fall within QuotaReserve, a fraction of the
+	// limit, only cached data is served. QuotaPath keeps the counts
+	// across restarts; empty keeps them in memory only.
+	DailyQuota   int64
+	MonthlyQuota int64
+	QuotaReserve float64
+	QuotaPath    string
+	// RecommendationsPath is a JSON catalog of cities and rules that
+	// extends the built-in recommendations. Empty uses the built-in ones.
+	RecommendationsPath string
 }
 
 func (c *Config) Validate() error {
-	if c.WeatherAPIKey != "" {
-		return nil
+	for _, provider := range c.providers() {
+		switch provider {
+		case ProviderWeatherAPI:
+			if c.WeatherAPIKey == "" {
+				return errors.New("WeatherAPIKey is required")
+			}
+		case ProviderOpenMeteo, ProviderNWS:
+		default:
+			return fmt.Errorf("unknown weather provider %q", provider)
+		}
+	}
+
+	if c.CurrentCacheTTL < 0 || c.ForecastCacheTTL < 0 || c.AlertsCacheTTL < 0 {
+		return errors.New("cache TTLs must not be negative")
+	}
+
+	if c.WeatherAPIRateLimit < 0 || (c.WeatherAPIRateLimit > 0 && c.WeatherAPIBurst < 1) {
+		return errors.New("the rate limit must not be negative and needs a burst of at least 1")
+	}
+
+	if c.DailyQuota < 0 || c.MonthlyQuota < 0 {
+		return errors.New("quotas must not be negative")
+	}
+
+	if c.QuotaReserve < 0 || c.QuotaReserve >= 1 {
+		return errors.New("the quota reserve must be a fraction from 0 up to 1")
+	}
+
+	return nil
+}
+
+// providers returns the configured provider names, defaulting to WeatherAPI.
+func (c *Config) providers() []string {
+	var names []string
+
+	for _, name := range strings.Split(c.WeatherProvider, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return []string{ProviderWeatherAPI}
 	}
 
-	return errors.New("WeatherAPIKey is required")
+	return names
 }
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Alerts(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		city, ok := request.Params.Arguments["city"].(string)
+		if !ok {
+			return mcp.NewToolResultError("city must be a string"), nil
+		}
+
+		data, err := svc.Weather().Alerts(ctx, city)
+		if err != nil {
+			return nil, err
+		}
+
+		return newStructuredResult("weather://alerts/"+url.PathEscape(city), data), nil
+	})
+}
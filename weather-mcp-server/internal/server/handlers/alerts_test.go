@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+)
+
+func TestAlerts(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                []mcp.Content
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"empty_city": {
+			wait: []mcp.Content{
+				mcp.NewTextContent("city must be a string"),
+			},
+		},
+		"city_not_found": {
+			arguments: map[string]any{
+				"city": "Tokyo",
+			},
+			errString: "weather API not available. Code: 400",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Alerts(context.Background(), "Tokyo").
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"city": "New York",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("✅ No active weather alerts for New York, USA."),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://alerts/New%20York",
+					MIMEType: "application/json",
+					Text:     `{"location":"New York, USA","count":0,"alerts":[]}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Alerts(context.Background(), "New York").
+					Return(&services.StructuredResult{
+						Summary: "✅ No active weather alerts for New York, USA.",
+						JSON:    `{"location":"New York, USA","count":0,"alerts":[]}`,
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := Alerts(svc)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tc.wait, result.Content)
+		})
+	}
+}
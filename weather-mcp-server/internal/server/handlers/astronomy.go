@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Astronomy(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		city, err := locationQuery(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		date, endDate, err := dateRange(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		data, err := svc.Weather().Astronomy(ctx, city, date, endDate)
+		if err != nil {
+			return nil, err
+		}
+
+		return newStructuredResult("weather://astronomy/"+url.PathEscape(city), data), nil
+	})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+)
+
+func TestAstronomy(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                []mcp.Content
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"invalid_date": {
+			arguments: map[string]any{
+				"city": "London",
+				"date": "21/06/2025",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("date must be a date such as 2025-06-21"),
+			},
+		},
+		"end_date_without_date": {
+			arguments: map[string]any{
+				"city":     "London",
+				"end_date": "2025-06-22",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("end_date needs a date"),
+			},
+		},
+		"end_date_before_date": {
+			arguments: map[string]any{
+				"city":     "London",
+				"date":     "2025-06-22",
+				"end_date": "2025-06-21",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("end_date must not be before date"),
+			},
+		},
+		"range_too_long": {
+			arguments: map[string]any{
+				"city":     "London",
+				"date":     "2025-06-21",
+				"end_date": "2025-06-28",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("end_date must be within 6 days of date"),
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"city":     "London",
+				"date":     "2025-06-21",
+				"end_date": " 2025-06-27 ",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("🌅 Sun and moon for London, United Kingdom"),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://astronomy/London",
+					MIMEType: "application/json",
+					Text:     `{"location":"London, United Kingdom","tz_id":"Europe/London"}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Astronomy(context.Background(), "London",
+						time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC),
+						time.Date(2025, time.June, 27, 0, 0, 0, 0, time.UTC)).
+					Return(&services.StructuredResult{
+						Summary: "🌅 Sun and moon for London, United Kingdom",
+						JSON:    `{"location":"London, United Kingdom","tz_id":"Europe/London"}`,
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := Astronomy(svc)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tc.wait, result.Content)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+// maxCompareLocations is the most locations one comparison accepts.
+const maxCompareLocations = 10
+
+func Compare(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		cities, err := cityList(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		system, err := unitSet(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		lang, err := language(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output, err := outputFormat(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		data, err := svc.Weather().Compare(ctx, cities, system, lang, output)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == services.OutputJSON {
+			return mcp.NewToolResultText(data.JSON), nil
+		}
+
+		segments := make([]string, 0, len(cities))
+		for _, city := range cities {
+			segments = append(segments, url.PathEscape(city))
+		}
+
+		return newStructuredResult("weather://compare/"+strings.Join(segments, "/"), data), nil
+	})
+}
+
+// cityList returns the provider queries of the cities argument. The error
+// message is meant for the caller.
+func cityList(arguments map[string]any) ([]string, error) {
+	values, ok := arguments["cities"].([]any)
+	if !ok || len(values) == 0 || len(values) > maxCompareLocations {
+		return nil, fmt.Errorf("cities must be a list of 1 to %d cities", maxCompareLocations)
+	}
+
+	cities := make([]string, 0, len(values))
+
+	for i, value := range values {
+		city, err := cityQuery(value)
+		if err != nil {
+			return nil, fmt.Errorf("cities[%d]: %w", i, err)
+		}
+
+		cities = append(cities, city)
+	}
+
+	return cities, nil
+}
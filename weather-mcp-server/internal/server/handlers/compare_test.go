@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                []mcp.Content
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"missing_cities": {
+			arguments: map[string]any{},
+			wait: []mcp.Content{
+				mcp.NewTextContent("cities must be a list of 1 to 10 cities"),
+			},
+		},
+		"too_many_cities": {
+			arguments: map[string]any{
+				"cities": []any{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("cities must be a list of 1 to 10 cities"),
+			},
+		},
+		"city_not_a_string": {
+			arguments: map[string]any{
+				"cities": []any{"London", float64(42)},
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("cities[1]: city must be a string"),
+			},
+		},
+		"malformed_location_id": {
+			arguments: map[string]any{
+				"cities": []any{"id:paris"},
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("cities[0]: a location id must look like id:2801268, use an id from search_locations"),
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"cities": []any{"London", "New York"},
+				"units":  "imperial",
+				"output": "markdown",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("## 🌍 Weather comparison"),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://compare/London/New%20York",
+					MIMEType: "application/json",
+					Text:     `{"lang":"en","locations":[]}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Compare(context.Background(), []string{"London", "New York"}, units.Imperial, "en", services.OutputMarkdown).
+					Return(&services.StructuredResult{
+						Summary: "## 🌍 Weather comparison",
+						JSON:    `{"lang":"en","locations":[]}`,
+					}, nil)
+			},
+		},
+		"json_output": {
+			arguments: map[string]any{
+				"cities": []any{"Paris"},
+				"lang":   "fr",
+				"output": "json",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent(`{"lang":"fr","locations":[]}`),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Compare(context.Background(), []string{"Paris"}, units.Metric, "fr", services.OutputJSON).
+					Return(&services.StructuredResult{
+						Summary: `{"lang":"fr","locations":[]}`,
+						JSON:    `{"lang":"fr","locations":[]}`,
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := Compare(svc)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tc.wait, result.Content)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+// withErrors turns service errors the user can act on into tool error
+// results, so that the model sees why the call failed. Other errors are
+// server faults and stay protocol errors.
+func withErrors(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil {
+			if result := toolError(err); result != nil {
+				return result, nil
+			}
+
+			return nil, err
+		}
+
+		return result, nil
+	}
+}
+
+// toolError returns a tool error result for a known service error, or nil
+// when err should be reported as a server fault.
+func toolError(err error) *mcp.CallToolResult {
+	if message, ok := services.ErrorMessage(err); ok {
+		return mcp.NewToolResultError(message)
+	}
+
+	return nil
+}
+
+// newStructuredResult returns the summary as text content and embeds
+// the JSON data as a resource so clients can consume either form.
+func newStructuredResult(uri string, result *services.StructuredResult) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(result.Summary),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     result.JSON,
+			}),
+		},
+	}
+}
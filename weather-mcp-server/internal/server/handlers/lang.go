@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
+)
+
+// language returns the language named by the lang argument, English by
+// default. The error message is meant for the caller.
+func language(arguments map[string]any) (string, error) {
+	value, exists := arguments["lang"]
+	if !exists {
+		return i18n.DefaultLanguage, nil
+	}
+
+	lang, ok := value.(string)
+	if !ok {
+		return "", errors.New("lang must be one of en, es, fr, de or ja")
+	}
+
+	catalog, ok := i18n.Lookup(lang)
+	if !ok {
+		return "", errors.New("lang must be one of en, es, fr, de or ja")
+	}
+
+	return catalog.Lang(), nil
+}
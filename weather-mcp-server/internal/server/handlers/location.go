@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+var (
+	// postcodePatterns match the formats of US zip codes, UK postcodes and
+	// Canadian postal codes; the provider decides whether one exists.
+	postcodePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+		regexp.MustCompile(`(?i)^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+		regexp.MustCompile(`(?i)^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	}
+	airportPattern = regexp.MustCompile(`^[A-Za-z]{3,4}$`)
+)
+
+// validPostcode reports whether postcode has the format of a postcode the
+// provider knows.
+func validPostcode(postcode string) bool {
+	return slices.ContainsFunc(postcodePatterns, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(postcode)
+	})
+}
+
+// locationQuery returns the provider query for the city argument or the
+// structured location argument, whichever is given. The error message is
+// meant for the caller.
+func locationQuery(arguments map[string]any) (string, error) {
+	cityValue, hasCity := arguments["city"]
+	locationValue, hasLocation := arguments["location"]
+
+	switch {
+	case hasCity && hasLocation:
+		return "", errors.New("set either city or location, not both")
+	case hasLocation:
+		location, ok := locationValue.(map[string]any)
+		if !ok {
+			return "", errors.New("location must be an object with lat and lon, postcode, airport or ip")
+		}
+
+		return structuredLocation(location)
+	case hasCity:
+		return cityQuery(cityValue)
+	default:
+		return "", errors.New("city or location is required")
+	}
+}
+
+// cityQuery returns the provider query for a city name, location id or
+// lat,lon coordinates. The error message is meant for the caller.
+func cityQuery(value any) (string, error) {
+	city, ok := value.(string)
+	if !ok {
+		return "", errors.New("city must be a string")
+	}
+
+	if _, ok := weatherapi.ParseLocationID(city); weatherapi.IsLocationID(city) && !ok {
+		return "", errors.New("a location id must look like id:2801268, use an id from search_locations")
+	}
+
+	return city, nil
+}
+
+func structuredLocation(location map[string]any) (string, error) {
+	var kinds []string
+
+	for field := range location {
+		switch field {
+		case "lat", "lon":
+			if !slices.Contains(kinds, "lat/lon") {
+				kinds = append(kinds, "lat/lon")
+			}
+		case "postcode", "airport", "ip":
+			kinds = append(kinds, field)
+		default:
+			return "", fmt.Errorf("location has an unknown field %q", field)
+		}
+	}
+
+	if len(kinds) != 1 {
+		return "", errors.New("location must have exactly one of lat and lon, postcode, airport or ip")
+	}
+
+	switch kinds[0] {
+	case "lat/lon":
+		return coordinates(location)
+	case "postcode":
+		postcode, ok := location["postcode"].(string)
+		if !ok || !validPostcode(strings.TrimSpace(postcode)) {
+			return "", errors.New("location.postcode must be a US zip code such as 10001, " +
+				"a UK postcode such as SW1A 1AA or a Canadian postal code such as K1A 0B1")
+		}
+
+		return strings.TrimSpace(postcode), nil
+	case "airport":
+		code, ok := location["airport"].(string)
+		if !ok || !airportPattern.MatchString(strings.TrimSpace(code)) {
+			return "", errors.New("location.airport must be a 3-letter IATA or 4-letter ICAO code")
+		}
+
+		return weatherapi.AirportQuery(code), nil
+	default:
+		ip, ok := location["ip"].(string)
+		if !ok {
+			return "", errors.New("location.ip must be an IP address or auto")
+		}
+
+		ip = strings.TrimSpace(ip)
+		if ip == "auto" {
+			return weatherapi.IPQuery(""), nil
+		}
+
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return "", errors.New("location.ip must be an IP address or auto")
+		}
+
+		return weatherapi.IPQuery(addr.String()), nil
+	}
+}
+
+func coordinates(location map[string]any) (string, error) {
+	lat, hasLat := location["lat"]
+	lon, hasLon := location["lon"]
+
+	if !hasLat || !hasLon {
+		return "", errors.New("location.lat and location.lon must be given together")
+	}
+
+	latNumber, ok := lat.(float64)
+	if !ok || latNumber < -90 || latNumber > 90 {
+		return "", errors.New("location.lat must be a number between -90 and 90")
+	}
+
+	lonNumber, ok := lon.(float64)
+	if !ok || lonNumber < -180 || lonNumber > 180 {
+		return "", errors.New("location.lon must be a number between -180 and 180")
+	}
+
+	return strconv.FormatFloat(latNumber, 'f', -1, 64) + "," + strconv.FormatFloat(lonNumber, 'f', -1, 64), nil
+}
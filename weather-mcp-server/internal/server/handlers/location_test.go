@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationQuery(t *testing.T) {
+	testCases := map[string]struct {
+		arguments map[string]any
+		errString string
+		wait      string
+	}{
+		"city": {
+			arguments: map[string]any{"city": "London"},
+			wait:      "London",
+		},
+		"city_and_location": {
+			arguments: map[string]any{"city": "London", "location": map[string]any{"ip": "auto"}},
+			errString: "set either city or location, not both",
+		},
+		"nothing": {
+			errString: "city or location is required",
+		},
+		"location_is_not_an_object": {
+			arguments: map[string]any{"location": "London"},
+			errString: "location must be an object with lat and lon, postcode, airport or ip",
+		},
+		"coordinates": {
+			arguments: map[string]any{"location": map[string]any{"lat": 48.8567, "lon": 2.3508}},
+			wait:      "48.8567,2.3508",
+		},
+		"latitude_without_longitude": {
+			arguments: map[string]any{"location": map[string]any{"lat": 48.8567}},
+			errString: "location.lat and location.lon must be given together",
+		},
+		"longitude_out_of_range": {
+			arguments: map[string]any{"location": map[string]any{"lat": 48.8567, "lon": 200.0}},
+			errString: "location.lon must be a number between -180 and 180",
+		},
+		"latitude_is_not_a_number": {
+			arguments: map[string]any{"location": map[string]any{"lat": "48.8567", "lon": 2.3508}},
+			errString: "location.lat must be a number between -90 and 90",
+		},
+		"us_zip_code": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "10001"}},
+			wait:      "10001",
+		},
+		"uk_postcode": {
+			arguments: map[string]any{"location": map[string]any{"postcode": " SW1A 1AA "}},
+			wait:      "SW1A 1AA",
+		},
+		"us_zip_plus_four": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "10001-1234"}},
+			wait:      "10001-1234",
+		},
+		"uk_postcode_lower_case": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "m1 1ae"}},
+			wait:      "m1 1ae",
+		},
+		"canadian_postal_code": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "K1A0B1"}},
+			wait:      "K1A0B1",
+		},
+		"invalid_postcode": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "not a real postcode"}},
+			errString: "location.postcode must be a US zip code such as 10001, " +
+				"a UK postcode such as SW1A 1AA or a Canadian postal code such as K1A 0B1",
+		},
+		"city_name_as_postcode": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "London"}},
+			errString: "location.postcode must be a US zip code such as 10001, " +
+				"a UK postcode such as SW1A 1AA or a Canadian postal code such as K1A 0B1",
+		},
+		"short_word_as_postcode": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "abc"}},
+			errString: "location.postcode must be a US zip code such as 10001, " +
+				"a UK postcode such as SW1A 1AA or a Canadian postal code such as K1A 0B1",
+		},
+		"uk_outward_code_only": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "SW1A"}},
+			errString: "location.postcode must be a US zip code such as 10001, " +
+				"a UK postcode such as SW1A 1AA or a Canadian postal code such as K1A 0B1",
+		},
+		"iata_code": {
+			arguments: map[string]any{"location": map[string]any{"airport": "jfk"}},
+			wait:      "iata:JFK",
+		},
+		"icao_code": {
+			arguments: map[string]any{"location": map[string]any{"airport": "KJFK"}},
+			wait:      "metar:KJFK",
+		},
+		"invalid_airport": {
+			arguments: map[string]any{"location": map[string]any{"airport": "JFK1"}},
+			errString: "location.airport must be a 3-letter IATA or 4-letter ICAO code",
+		},
+		"auto_ip": {
+			arguments: map[string]any{"location": map[string]any{"ip": "auto"}},
+			wait:      "auto:ip",
+		},
+		"auto_ip_with_spaces": {
+			arguments: map[string]any{"location": map[string]any{"ip": " auto "}},
+			wait:      "auto:ip",
+		},
+		"ip_address": {
+			arguments: map[string]any{"location": map[string]any{"ip": "2001:db8::1"}},
+			wait:      "2001:db8::1",
+		},
+		"invalid_ip": {
+			arguments: map[string]any{"location": map[string]any{"ip": "300.1.1.1"}},
+			errString: "location.ip must be an IP address or auto",
+		},
+		"several_kinds": {
+			arguments: map[string]any{"location": map[string]any{"postcode": "10001", "airport": "JFK"}},
+			errString: "location must have exactly one of lat and lon, postcode, airport or ip",
+		},
+		"unknown_field": {
+			arguments: map[string]any{"location": map[string]any{"zip": "10001"}},
+			errString: `location has an unknown field "zip"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, err := locationQuery(tc.arguments)
+			if tc.errString != "" {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wait, query)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+// maxMarineDays is the most days of marine forecast WeatherAPI serves.
+const maxMarineDays = 7
+
+func Marine(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		city, err := locationQuery(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		days, err := dayCount(request.Params.Arguments, 1, maxMarineDays)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		system, err := unitSet(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		data, err := svc.Weather().Marine(ctx, city, days, system)
+		if err != nil {
+			return nil, err
+		}
+
+		return newStructuredResult("weather://marine/"+url.PathEscape(city), data), nil
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+)
+
+func TestMarine(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                []mcp.Content
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"too_many_days": {
+			arguments: map[string]any{
+				"city": "Brighton",
+				"days": float64(8),
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("days must be an integer between 1 and 7"),
+			},
+		},
+		"fractional_days": {
+			arguments: map[string]any{
+				"city": "Brighton",
+				"days": 1.5,
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("days must be an integer between 1 and 7"),
+			},
+		},
+		"no_marine_data": {
+			arguments: map[string]any{
+				"city": "Madrid",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("No marine data is available for this location, try a place on the coast"),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Marine(context.Background(), "Madrid", 1, units.Metric).
+					Return(nil, services.ErrNoMarineData)
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"city":  "Brighton",
+				"days":  float64(3),
+				"units": "imperial",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("🌊 Marine forecast for Brighton, United Kingdom"),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://marine/Brighton",
+					MIMEType: "application/json",
+					Text:     `{"location":"Brighton, United Kingdom"}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Marine(context.Background(), "Brighton", 3, units.Imperial).
+					Return(&services.StructuredResult{
+						Summary: "🌊 Marine forecast for Brighton, United Kingdom",
+						JSON:    `{"location":"Brighton, United Kingdom"}`,
+					}, nil)
+			},
+		},
+		"default_days": {
+			arguments: map[string]any{
+				"city": "Brighton",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("🌊 Marine forecast for Brighton, United Kingdom"),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://marine/Brighton",
+					MIMEType: "application/json",
+					Text:     `{"location":"Brighton, United Kingdom"}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Marine(context.Background(), "Brighton", 1, units.Metric).
+					Return(&services.StructuredResult{
+						Summary: "🌊 Marine forecast for Brighton, United Kingdom",
+						JSON:    `{"location":"Brighton, United Kingdom"}`,
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := Marine(svc)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tc.wait, result.Content)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+// outputFormat returns the format named by the output argument, HTML by
+// default. The error message is meant for the caller.
+func outputFormat(arguments map[string]any) (services.Output, error) {
+	value, exists := arguments["output"]
+	if !exists {
+		return services.OutputHTML, nil
+	}
+
+	name, ok := value.(string)
+	if !ok || !slices.Contains(services.Outputs(), name) {
+		return "", errors.New("output must be one of html, markdown, json or text")
+	}
+
+	return services.Output(name), nil
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func QuotaStatus(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		data, err := svc.Quota().Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return newStructuredResult("weather://quota", data), nil
+	})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+)
+
+func TestQuotaStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksQuota := mock.NewMockQuotaService(ctrl)
+	mocksQuota.EXPECT().
+		Status(context.Background()).
+		Return(&services.StructuredResult{
+			Summary: "ℹ️ No call budget is kept for the current weather provider.",
+			JSON:    `{"configured":false}`,
+		}, nil)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Quota().Return(mocksQuota)
+
+	result, err := QuotaStatus(svc)(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []mcp.Content{
+		mcp.NewTextContent("ℹ️ No call budget is kept for the current weather provider."),
+		mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      "weather://quota",
+			MIMEType: "application/json",
+			Text:     `{"configured":false}`,
+		}),
+	}, result.Content)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func SearchLocations(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query, ok := request.Params.Arguments["query"].(string)
+		if !ok || strings.TrimSpace(query) == "" {
+			return mcp.NewToolResultError("query must be a non-empty string"), nil
+		}
+
+		data, err := svc.Weather().SearchLocations(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return newStructuredResult("weather://locations/"+url.PathEscape(query), data), nil
+	})
+}
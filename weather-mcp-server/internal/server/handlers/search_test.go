@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+)
+
+func TestSearchLocations(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                []mcp.Content
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"empty_query": {
+			arguments: map[string]any{
+				"query": " ",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("query must be a non-empty string"),
+			},
+		},
+		"unsupported_provider": {
+			arguments: map[string]any{
+				"query": "Springfield",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("The configured weather provider does not offer this data"),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					SearchLocations(context.Background(), "Springfield").
+					Return(nil, errors.ErrUnsupported)
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"query": "Saint Louis",
+			},
+			wait: []mcp.Content{
+				mcp.NewTextContent("🔎 1 location(s) match \"Saint Louis\""),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      "weather://locations/Saint%20Louis",
+					MIMEType: "application/json",
+					Text:     `{"query":"Saint Louis","count":1}`,
+				}),
+			},
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					SearchLocations(context.Background(), "Saint Louis").
+					Return(&services.StructuredResult{
+						Summary: "🔎 1 location(s) match \"Saint Louis\"",
+						JSON:    `{"query":"Saint Louis","count":1}`,
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := SearchLocations(svc)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tc.wait, result.Content)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+)
+
+// unitSet returns the unit system named by the units argument, metric by
+// default, with wind speeds in the wind_unit argument if it is given. The
+// error message is meant for the caller.
+func unitSet(arguments map[string]any) (units.Set, error) {
+	system := units.Metric
+
+	if value, exists := arguments["units"]; exists {
+		name, ok := value.(string)
+		if !ok {
+			return system, errors.New("units must be one of metric, imperial, uk or scientific")
+		}
+
+		if system, ok = units.Lookup(name); !ok {
+			return system, errors.New("units must be one of metric, imperial, uk or scientific")
+		}
+	}
+
+	if value, exists := arguments["wind_unit"]; exists {
+		name, ok := value.(string)
+		if !ok {
+			return system, errors.New("wind_unit must be one of kph, mph, mps, knots or beaufort")
+		}
+
+		if system, ok = system.WithWind(name); !ok {
+			return system, errors.New("wind_unit must be one of kph, mph, mps, knots or beaufort")
+		}
+	}
+
+	return system, nil
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -10,17 +11,67 @@ import (
 )
 
 func CurrentWeather(svc services.Services) server.ToolHandlerFunc {
-	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		city, err := locationQuery(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		system, err := unitSet(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		lang, err := language(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		output, err := outputFormat(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		data, err := svc.Weather().Current(ctx, city, system, lang, output)
+		if err != nil {
+			return nil, err
+		}
+
+		// The JSON output is the structured data itself, so it is not
+		// embedded a second time.
+		if output == services.OutputJSON {
+			return mcp.NewToolResultText(data.JSON), nil
+		}
+
+		return newStructuredResult("weather://current/"+url.PathEscape(city), data), nil
+	})
+}
+
+// maxForecastDays is the most days of forecast WeatherAPI serves.
+const maxForecastDays = 14
+
+func Forecast(svc services.Services) server.ToolHandlerFunc {
+	return withErrors(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		city, ok := request.Params.Arguments["city"].(string)
 		if !ok {
 			return mcp.NewToolResultError("city must be a string"), nil
 		}
 
-		data, err := svc.Weather().Current(ctx, city)
+		days, err := dayCount(request.Params.Arguments, 3, maxForecastDays)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		system, err := unitSet(request.Params.Arguments)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		data, err := svc.Weather().Forecast(ctx, city, days, system)
 		if err != nil {
 			return nil, err
 		}
 
 		return mcp.NewToolResultText(data), nil
-	}
+	})
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -10,7 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
 )
 
 func TestCurrentWeather(t *testing.T) {
@@ -18,11 +22,93 @@ func TestCurrentWeather(t *testing.T) {
 		arguments           map[string]any
 		errString           string
 		wait                string
+		resource            string
 		setupWeatherService func(mocksWeather *mock.MockWeatherService)
 	}{
 		"empty_city": {
+			wait: "city or location is required",
+		},
+		"city_is_not_a_string": {
+			arguments: map[string]any{
+				"city": float64(42),
+			},
 			wait: "city must be a string",
 		},
+		"airport_location": {
+			arguments: map[string]any{
+				"location": map[string]any{"airport": "lhr"},
+			},
+			wait: "<h1>Heathrow weather data</h1>",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "iata:LHR", units.Metric, "en", services.OutputHTML).
+					Return(&services.StructuredResult{Summary: "<h1>Heathrow weather data</h1>", JSON: `{}`}, nil)
+			},
+		},
+		"spanish_output": {
+			arguments: map[string]any{
+				"city": "Madrid",
+				"lang": "ES",
+			},
+			wait: "<h1>Tiempo en Madrid</h1>",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Madrid", units.Metric, "es", services.OutputHTML).
+					Return(&services.StructuredResult{Summary: "<h1>Tiempo en Madrid</h1>", JSON: `{}`}, nil)
+			},
+		},
+		"unknown_language": {
+			arguments: map[string]any{
+				"city": "Madrid",
+				"lang": "pt",
+			},
+			wait: "lang must be one of en, es, fr, de or ja",
+		},
+		"invalid_location": {
+			arguments: map[string]any{
+				"location": map[string]any{"lat": float64(95), "lon": float64(0)},
+			},
+			wait: "location.lat must be a number between -90 and 90",
+		},
+		"uk_units_with_knots": {
+			arguments: map[string]any{
+				"city":      "Cowes",
+				"units":     "uk",
+				"wind_unit": "knots",
+			},
+			wait: "<h1>Cowes weather data</h1>",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				wind, _ := units.UK.WithWind("knots")
+
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Cowes", wind, "en", services.OutputHTML).
+					Return(&services.StructuredResult{Summary: "<h1>Cowes weather data</h1>", JSON: `{}`}, nil)
+			},
+		},
+		"unknown_wind_unit": {
+			arguments: map[string]any{
+				"city":      "Cowes",
+				"wind_unit": "furlongs",
+			},
+			wait: "wind_unit must be one of kph, mph, mps, knots or beaufort",
+		},
+		"malformed_location_id": {
+			arguments: map[string]any{
+				"city": "id:springfield",
+			},
+			wait: "a location id must look like id:2801268, use an id from search_locations",
+		},
+		"location_id": {
+			arguments: map[string]any{
+				"city": "id:2618724",
+			},
+			wait: "<h1>Springfield weather data</h1>",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "id:2618724", units.Metric, "en", services.OutputHTML).
+					Return(&services.StructuredResult{Summary: "<h1>Springfield weather data</h1>", JSON: `{}`}, nil)
+			},
+		},
 		"city_not_found": {
 			arguments: map[string]any{
 				"city": "Tokyo",
@@ -30,19 +116,176 @@ func TestCurrentWeather(t *testing.T) {
 			errString: "weather API not available. Code: 400",
 			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
 				mocksWeather.EXPECT().
-					Current(context.Background(), "Tokyo").
-					Return("", errors.New("weather API not available. Code: 400"))
+					Current(context.Background(), "Tokyo", units.Metric, "en", services.OutputHTML).
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"unknown_location": {
+			arguments: map[string]any{
+				"city": "Atlantis",
+			},
+			wait: "No location matches the query, check the spelling or try coordinates",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Atlantis", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.StatusError{API: "weather", StatusCode: 400, Code: 1006})
+			},
+		},
+		"location_without_suggestions": {
+			arguments: map[string]any{
+				"city": "Atlantis",
+			},
+			wait: "No location matches \"Atlantis\", check the spelling or try coordinates",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Atlantis", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.LocationError{Query: "Atlantis"})
+			},
+		},
+		"location_with_suggestions": {
+			arguments: map[string]any{
+				"city": "Pariss",
+			},
+			wait: "No location matches \"Pariss\", did you mean Paris, France or Paris, United States?",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Pariss", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.LocationError{Query: "Pariss", Suggestions: []string{"Paris, France", "Paris, United States"}})
+			},
+		},
+		"invalid_key": {
+			arguments: map[string]any{
+				"city": "Berlin",
+			},
+			wait: "The weather API key is missing or invalid, check the server configuration",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Berlin", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.StatusError{API: "weather", StatusCode: 401, Code: 2006})
+			},
+		},
+		"quota_exceeded": {
+			arguments: map[string]any{
+				"city": "Paris",
+			},
+			wait: "The weather API key has used up its monthly quota, try again next month",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Paris", units.Metric, "en", services.OutputHTML).
+					Return(nil, fmt.Errorf("current weather: %w", weatherapi.ErrQuotaExceeded))
+			},
+		},
+		"access_denied": {
+			arguments: map[string]any{
+				"city": "Madrid",
+			},
+			wait: "The weather API key is disabled or cannot access this data",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Madrid", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.StatusError{API: "weather", StatusCode: 403, Code: 2008})
+			},
+		},
+		"budget_low": {
+			arguments: map[string]any{
+				"city": "Rome",
+			},
+			wait: "The weather API call budget is nearly used up and nothing is cached for this request, check quota_status or try again later",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Rome", units.Metric, "en", services.OutputHTML).
+					Return(nil, weatherapi.ErrBudgetLow)
+			},
+		},
+		"bad_request": {
+			arguments: map[string]any{
+				"city": "Oslo",
+			},
+			wait: "The weather API rejected the request, check the arguments",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Oslo", units.Metric, "en", services.OutputHTML).
+					Return(nil, &weatherapi.StatusError{API: "weather", StatusCode: 400, Code: 1003})
+			},
+		},
+		"provider_unavailable": {
+			arguments: map[string]any{
+				"city": "Vienna",
+			},
+			wait: "The weather API is temporarily unavailable, try again later",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Vienna", units.Metric, "en", services.OutputHTML).
+					Return(nil, errors.Join(&weatherapi.StatusError{API: "weather", StatusCode: 502}, &weatherapi.StatusError{API: "open-meteo", StatusCode: 503}))
+			},
+		},
+		"timeout": {
+			arguments: map[string]any{
+				"city": "Lisbon",
+			},
+			wait: "The weather API did not respond in time, try again later",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Lisbon", units.Metric, "en", services.OutputHTML).
+					Return(nil, fmt.Errorf("get: %w", context.DeadlineExceeded))
 			},
 		},
+		"unsupported": {
+			arguments: map[string]any{
+				"city": "Sydney",
+			},
+			wait: "The configured weather provider does not offer this data",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Sydney", units.Metric, "en", services.OutputHTML).
+					Return(nil, fmt.Errorf("current weather: %w", errors.ErrUnsupported))
+			},
+		},
+		"markdown_output": {
+			arguments: map[string]any{
+				"city":   "New York",
+				"output": "markdown",
+			},
+			wait:     "## New York, United States of America",
+			resource: "weather://current/New%20York",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "New York", units.Metric, "en", services.OutputMarkdown).
+					Return(&services.StructuredResult{Summary: "## New York, United States of America", JSON: `{}`}, nil)
+			},
+		},
+		"json_output": {
+			arguments: map[string]any{
+				"city":   "Oslo",
+				"output": "json",
+			},
+			wait: `{"schema":"weather://schema/current_weather/v1"}`,
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Current(context.Background(), "Oslo", units.Metric, "en", services.OutputJSON).
+					Return(&services.StructuredResult{
+						Summary: `{"schema":"weather://schema/current_weather/v1"}`,
+						JSON:    `{"schema":"weather://schema/current_weather/v1"}`,
+					}, nil)
+			},
+		},
+		"unknown_output": {
+			arguments: map[string]any{
+				"city":   "Oslo",
+				"output": "pdf",
+			},
+			wait: "output must be one of html, markdown, json or text",
+		},
 		"successful_request": {
 			arguments: map[string]any{
 				"city": "London",
 			},
-			wait: "<h1>London weather data</h1>",
+			wait:     "<h1>London weather data</h1>",
+			resource: "weather://current/London",
 			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
 				mocksWeather.EXPECT().
-					Current(context.Background(), "London").
-					Return("<h1>London weather data</h1>", nil)
+					Current(context.Background(), "London", units.Metric, "en", services.OutputHTML).
+					Return(&services.StructuredResult{Summary: "<h1>London weather data</h1>", JSON: `{}`}, nil)
 			},
 		},
 	}
@@ -57,6 +300,111 @@ func TestCurrentWeather(t *testing.T) {
 
 	handler := CurrentWeather(svc)
 
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherService != nil {
+				tc.setupWeatherService(mocksWeather)
+			}
+
+			var request mcp.CallToolRequest
+			request.Params.Arguments = tc.arguments
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NotEmpty(t, result.Content)
+			content, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+
+			assert.Equal(t, tc.wait, content.Text)
+
+			if tc.resource != "" {
+				require.Len(t, result.Content, 2)
+				embedded, ok := result.Content[1].(mcp.EmbeddedResource)
+				require.True(t, ok)
+
+				resource, ok := embedded.Resource.(mcp.TextResourceContents)
+				require.True(t, ok)
+				assert.Equal(t, tc.resource, resource.URI)
+			}
+		})
+	}
+}
+
+func TestForecast(t *testing.T) {
+	testCases := map[string]struct {
+		arguments           map[string]any
+		errString           string
+		wait                string
+		setupWeatherService func(mocksWeather *mock.MockWeatherService)
+	}{
+		"empty_city": {
+			wait: "city must be a string",
+		},
+		"days_out_of_range": {
+			arguments: map[string]any{
+				"city": "London",
+				"days": float64(15),
+			},
+			wait: "days must be an integer between 1 and 14",
+		},
+		"unknown_units": {
+			arguments: map[string]any{
+				"city":  "London",
+				"units": "kelvin",
+			},
+			wait: "units must be one of metric, imperial, uk or scientific",
+		},
+		"city_not_found": {
+			arguments: map[string]any{
+				"city": "Tokyo",
+			},
+			errString: "weather API not available. Code: 400",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Forecast(context.Background(), "Tokyo", 3, units.Metric).
+					Return("", errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"unknown_location": {
+			arguments: map[string]any{
+				"city": "Atlantis",
+			},
+			wait: "No location matches \"Atlantis\", check the spelling or try coordinates",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Forecast(context.Background(), "Atlantis", 3, units.Metric).
+					Return("", &weatherapi.LocationError{Query: "Atlantis"})
+			},
+		},
+		"successful_request": {
+			arguments: map[string]any{
+				"city":  "London",
+				"days":  float64(7),
+				"units": "imperial",
+			},
+			wait: "<h1>London forecast</h1>",
+			setupWeatherService: func(mocksWeather *mock.MockWeatherService) {
+				mocksWeather.EXPECT().
+					Forecast(context.Background(), "London", 7, units.Imperial).
+					Return("<h1>London forecast</h1>", nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mocksWeather := mock.NewMockWeatherService(ctrl)
+
+	svc := mock.NewMockServices(ctrl)
+	svc.EXPECT().Weather().Return(mocksWeather).AnyTimes()
+
+	handler := Forecast(svc)
+
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
 			if tc.setupWeatherService != nil {
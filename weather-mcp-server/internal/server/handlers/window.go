@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDateRange is the most days a date range may span.
+const maxDateRange = 7
+
+// dateRange returns the date and end_date arguments. Both are zero when date
+// is not given, and the end date is zero for a single day. The error
+// message is meant for the caller.
+func dateRange(arguments map[string]any) (time.Time, time.Time, error) {
+	date, err := localDate(arguments, "date")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endDate, err := localDate(arguments, "end_date")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	switch {
+	case endDate.IsZero():
+	case date.IsZero():
+		return time.Time{}, time.Time{}, errors.New("end_date needs a date")
+	case endDate.Before(date):
+		return time.Time{}, time.Time{}, errors.New("end_date must not be before date")
+	case endDate.Sub(date) >= maxDateRange*24*time.Hour:
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must be within %d days of date", maxDateRange-1)
+	}
+
+	return date, endDate, nil
+}
+
+// dayCount returns the days argument, an integer from 1 to maxDays, or
+// defaultDays when it is not given. The error message is meant for the
+// caller.
+func dayCount(arguments map[string]any, defaultDays, maxDays int) (int, error) {
+	value, exists := arguments["days"]
+	if !exists {
+		return defaultDays, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number != float64(int(number)) || number < 1 || number > float64(maxDays) {
+		return 0, fmt.Errorf("days must be an integer between 1 and %d", maxDays)
+	}
+
+	return int(number), nil
+}
+
+func localDate(arguments map[string]any, name string) (time.Time, error) {
+	value, exists := arguments[name]
+	if !exists {
+		return time.Time{}, nil
+	}
+
+	if text, ok := value.(string); ok {
+		if date, err := time.Parse(time.DateOnly, strings.TrimSpace(text)); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%s must be a date such as 2025-06-21", name)
+}
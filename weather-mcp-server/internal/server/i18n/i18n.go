@@ -0,0 +1,97 @@
+// Package i18n holds the message catalogs used to render weather output in
+// the user's language.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultLanguage is used for unknown languages and missing messages.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// languages lists the shipped catalogs, starting with the default.
+var languages = []string{"en", "es", "fr", "de", "ja"}
+
+var catalogs = loadCatalogs()
+
+// Catalog is the set of messages for one language.
+type Catalog struct {
+	lang     string
+	messages map[string]string
+}
+
+func loadCatalogs() map[string]*Catalog {
+	catalogs := make(map[string]*Catalog, len(languages))
+
+	for _, lang := range languages {
+		data, err := locales.ReadFile(path.Join("locales", lang+".json"))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: %s catalog: %v", lang, err))
+		}
+
+		catalogs[lang] = &Catalog{lang: lang, messages: messages}
+	}
+
+	return catalogs
+}
+
+// Languages returns the language codes that have a catalog.
+func Languages() []string {
+	return languages
+}
+
+// Lookup returns the catalog for lang.
+func Lookup(lang string) (*Catalog, bool) {
+	catalog, ok := catalogs[strings.ToLower(lang)]
+
+	return catalog, ok
+}
+
+// For returns the catalog for lang, or the default catalog if there is none.
+func For(lang string) *Catalog {
+	if catalog, ok := Lookup(lang); ok {
+		return catalog
+	}
+
+	return catalogs[DefaultLanguage]
+}
+
+func (c *Catalog) Lang() string {
+	return c.lang
+}
+
+// Has reports whether key has a message in this catalog.
+func (c *Catalog) Has(key string) bool {
+	_, ok := c.messages[key]
+
+	return ok
+}
+
+// Text returns the message for key formatted with args. A message missing
+// from the catalog falls back to the default language, then to the key.
+func (c *Catalog) Text(key string, args ...any) string {
+	message, ok := c.messages[key]
+	if !ok {
+		if message, ok = catalogs[DefaultLanguage].messages[key]; !ok {
+			return key
+		}
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
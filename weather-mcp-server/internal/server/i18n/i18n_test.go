@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+func TestCatalogsHaveEveryKey(t *testing.T) {
+	t.Parallel()
+
+	english, ok := Lookup(DefaultLanguage)
+	require.True(t, ok)
+
+	for _, lang := range Languages() {
+		t.Run(lang, func(t *testing.T) {
+			t.Parallel()
+
+			catalog, ok := Lookup(lang)
+			require.True(t, ok)
+
+			for key, message := range english.messages {
+				translated, ok := catalog.messages[key]
+				if !assert.True(t, ok, "missing %q", key) {
+					continue
+				}
+
+				assert.NotEmpty(t, translated, "empty %q", key)
+				assert.Equal(t, verbPattern.FindAllString(message, -1), verbPattern.FindAllString(translated, -1),
+					"format verbs of %q", key)
+			}
+
+			for key := range catalog.messages {
+				assert.True(t, english.Has(key), "unknown %q", key)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		lang string
+		key  string
+		args []any
+		wait string
+	}{
+		"translated": {
+			lang: "de",
+			key:  "label.humidity",
+			wait: "Luftfeuchtigkeit",
+		},
+		"formatted": {
+			lang: "fr",
+			key:  "consensus",
+			args: []any{3, "1.5°C", 2, 3},
+			wait: "Combiné à partir de 3 sources : températures à 1.5°C près, 2 sur 3 s'accordent sur les conditions",
+		},
+		"unknown_language": {
+			lang: "xx",
+			key:  "label.pressure",
+			wait: "Pressure",
+		},
+		"unknown_key": {
+			lang: "ja",
+			key:  "label.missing",
+			wait: "label.missing",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.wait, For(tc.lang).Text(tc.key, tc.args...))
+		})
+	}
+}
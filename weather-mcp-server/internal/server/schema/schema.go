@@ -0,0 +1,39 @@
+// Package schema holds the JSON Schemas of the structured tool output, so
+// that clients can validate it against a fixed version.
+package schema
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CurrentWeatherURI identifies version 1 of the current weather report.
+// Fields are only added within a version; anything else gets a new one.
+const CurrentWeatherURI = "weather://schema/current_weather/v1"
+
+//go:embed current_weather.v1.json
+var currentWeather string
+
+// CurrentWeather returns the resource that serves the current weather
+// report schema.
+func CurrentWeather() (mcp.Resource, server.ResourceHandlerFunc) {
+	resource := mcp.NewResource(CurrentWeatherURI, "Current weather schema",
+		mcp.WithResourceDescription("JSON Schema of the structured output of current_weather"),
+		mcp.WithMIMEType("application/schema+json"),
+	)
+
+	handler := func(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      CurrentWeatherURI,
+				MIMEType: "application/schema+json",
+				Text:     currentWeather,
+			},
+		}, nil
+	}
+
+	return resource, handler
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentWeather(t *testing.T) {
+	resource, handler := CurrentWeather()
+
+	assert.Equal(t, CurrentWeatherURI, resource.URI)
+
+	contents, err := handler(context.Background(), mcp.ReadResourceRequest{})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+
+	var document struct {
+		ID         string         `json:"$id"`
+		Required   []string       `json:"required"`
+		Properties map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &document))
+
+	assert.Equal(t, CurrentWeatherURI, document.ID)
+
+	for _, field := range document.Required {
+		assert.Contains(t, document.Properties, field)
+	}
+}
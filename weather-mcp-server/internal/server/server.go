@@ -5,13 +5,23 @@ import (
 	"embed"
 	"html/template"
 	"log"
+	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"syscall"
 
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/TuanKiri/weather-mcp-server/internal/server/schema"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/cache"
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services/core"
 	"github.com/TuanKiri/weather-mcp-server/internal/server/tools"
+	"github.com/TuanKiri/weather-mcp-server/pkg/composite"
+	"github.com/TuanKiri/weather-mcp-server/pkg/nws"
+	"github.com/TuanKiri/weather-mcp-server/pkg/openmeteo"
+	"github.com/TuanKiri/weather-mcp-server/pkg/recommend"
 	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
 )
 
@@ -24,9 +34,32 @@ func Run(cfg *Config) error {
 		return err
 	}
 
-	wApi := weatherapi.New(cfg.WeatherAPIKey, cfg.WeatherAPITimeout)
+	budget, err := newBudget(cfg)
+	if err != nil {
+		return err
+	}
+
+	store, closeStore := newCacheStore(cfg)
+	defer closeStore()
 
-	svc := core.New(tmpl, wApi)
+	weatherAPI := cache.New(newProvider(cfg, budget), store, cache.TTL{
+		Current:  cfg.CurrentCacheTTL,
+		Forecast: cfg.ForecastCacheTTL,
+		Alerts:   cfg.AlertsCacheTTL,
+	})
+	defer logCacheStats(weatherAPI)
+
+	var quota services.QuotaProvider
+	if budget != nil {
+		quota = budget
+	}
+
+	recommendations, err := newRecommendations(cfg)
+	if err != nil {
+		return err
+	}
+
+	svc := core.New(tmpl, weatherAPI, quota, recommendations)
 
 	s := server.NewMCPServer(
 		"Weather Server",
@@ -36,12 +69,22 @@ func Run(cfg *Config) error {
 
 	toolFuncs := []tools.ToolFunc{
 		tools.CurrentWeather,
+		tools.Compare,
+		tools.Forecast,
+		tools.AirQuality,
+		tools.Astronomy,
+		tools.Marine,
+		tools.Alerts,
+		tools.SearchLocations,
+		tools.QuotaStatus,
 	}
 
 	for _, tool := range toolFuncs {
 		s.AddTool(tool(svc))
 	}
 
+	s.AddResource(schema.CurrentWeather())
+
 	if cfg.ListenAddr != "" {
 		return serveSSE(s, cfg.ListenAddr)
 	}
@@ -49,6 +92,103 @@ func Run(cfg *Config) error {
 	return server.ServeStdio(s)
 }
 
+// newCacheStore returns the memory cache, backed by the file at CachePath
+// when one is configured. A file that cannot be opened, for example because
+// another server instance holds it, only disables persistence.
+func newCacheStore(cfg *Config) (cache.Store, func()) {
+	memory := cache.NewMemoryStore()
+
+	if cfg.CachePath == "" {
+		return memory, func() {}
+	}
+
+	disk, err := cache.OpenBoltStore(cfg.CachePath)
+	if err != nil {
+		log.Printf("persistent cache disabled: %v", err)
+		return memory, func() {}
+	}
+
+	return cache.Layered(memory, disk), func() {
+		if err := disk.Close(); err != nil {
+			log.Printf("close persistent cache: %v", err)
+		}
+	}
+}
+
+func logCacheStats(weatherAPI *cache.Provider) {
+	stats := weatherAPI.Stats()
+
+	log.Printf("weather cache: %d hits, %d misses, %d upstream fetches, %d stale",
+		stats.Hits, stats.Misses, stats.Fetches, stats.Stale)
+}
+
+// newRecommendations returns the built-in recommendation catalog, extended
+// with the catalog file at RecommendationsPath when one is configured.
+func newRecommendations(cfg *Config) (*recommend.Catalog, error) {
+	if cfg.RecommendationsPath == "" {
+		return recommend.Default(), nil
+	}
+
+	return recommend.Load(cfg.RecommendationsPath)
+}
+
+// newBudget returns the WeatherAPI call budget, or nil when WeatherAPI
+// is not one of the configured providers.
+func newBudget(cfg *Config) (*weatherapi.Budget, error) {
+	if !slices.Contains(cfg.providers(), ProviderWeatherAPI) {
+		return nil, nil
+	}
+
+	// A directory that cannot be created shows up as a persist error in
+	// the quota status, so the server still starts.
+	if cfg.QuotaPath != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.QuotaPath), 0o700); err != nil {
+			log.Printf("quota counts directory: %v", err)
+		}
+	}
+
+	return weatherapi.NewBudget(cfg.QuotaPath, cfg.DailyQuota, cfg.MonthlyQuota, cfg.QuotaReserve)
+}
+
+// newProvider returns the weather backend selected in the config, wrapping
+// several backends into a composite provider.
+func newProvider(cfg *Config, budget *weatherapi.Budget) services.WeatherAPIProvider {
+	names := cfg.providers()
+	if len(names) == 1 {
+		return newBackend(cfg, names[0], budget)
+	}
+
+	backends := make([]composite.Provider, 0, len(names))
+	for _, name := range names {
+		backends = append(backends, newBackend(cfg, name, budget))
+	}
+
+	mode := composite.Failover
+	if cfg.WeatherConsensus {
+		mode = composite.Consensus
+	}
+
+	return composite.New(mode, backends...)
+}
+
+// newBackend returns a single weather backend. The NWS API only accepts
+// coordinates, so it geocodes through Open-Meteo.
+func newBackend(cfg *Config, name string, budget *weatherapi.Budget) services.WeatherAPIProvider {
+	switch name {
+	case ProviderOpenMeteo:
+		return openmeteo.New(cfg.WeatherAPITimeout)
+	case ProviderNWS:
+		return nws.New(cfg.WeatherAPITimeout, openmeteo.New(cfg.WeatherAPITimeout))
+	default:
+		opts := []weatherapi.ClientOption{weatherapi.WithBudget(budget)}
+		if cfg.WeatherAPIRateLimit > 0 {
+			opts = append(opts, weatherapi.WithRateLimit(cfg.WeatherAPIRateLimit, cfg.WeatherAPIBurst))
+		}
+
+		return weatherapi.New(cfg.WeatherAPIKey, cfg.WeatherAPITimeout, opts...)
+	}
+}
+
 func serveSSE(s *server.MCPServer, addr string) error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucket = []byte("responses")
+
+// staleLimit is how long past its expiry an entry is kept on disk. Expired
+// entries are still served while the call budget is low or the provider is
+// down, so they survive restarts until they are too old to be of use.
+const staleLimit = 7 * 24 * time.Hour
+
+// BoltStore persists entries in a BoltDB file so that they survive restarts.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the file at path and drops the entries that
+// expired more than staleLimit ago.
+// Another process holding the file makes it fail after a second instead of
+// blocking.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+
+		var stale [][]byte
+
+		if err := b.ForEach(func(k, v []byte) error {
+			var e Entry
+			if err := json.Unmarshal(v, &e); err != nil || e.expired(now.Add(-staleLimit)) {
+				stale = append(stale, append([]byte(nil), k...))
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range stale {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Get(key string) (Entry, bool) {
+	var e Entry
+
+	found := false
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucket).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+
+		if err := json.Unmarshal(v, &e); err != nil {
+			return err
+		}
+
+		found = true
+
+		return nil
+	}); err != nil {
+		log.Printf("cache: read %q: %v", key, err)
+		return Entry{}, false
+	}
+
+	return e, found
+}
+
+// Set writes the entry. Failures are logged rather than returned because
+// the response has already been served.
+func (s *BoltStore) Set(key string, entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("cache: encode %q: %v", key, err)
+		return
+	}
+
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	}); err != nil {
+		log.Printf("cache: write %q: %v", key, err)
+	}
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,295 @@
+// Package cache decorates a weather provider with response caching so that
+// repeated questions about the same place do not spend upstream quota.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// forever caches an entry without expiry.
+const forever time.Duration = -1
+
+// fetchTimeout bounds an upstream call shared by concurrent requests.
+const fetchTimeout = 30 * time.Second
+
+// searchTTL is how long search results are cached. Places rarely change,
+// so they are kept for a day whatever the endpoint TTLs are.
+const searchTTL = 24 * time.Hour
+
+// TTL sets how long each endpoint's responses are cached. A zero TTL
+// disables caching for that endpoint. History of past days never changes
+// and is cached without expiry.
+type TTL struct {
+	Current  time.Duration
+	Forecast time.Duration
+	Alerts   time.Duration
+}
+
+type Stats struct {
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Fetches int64 `json:"fetches"`
+	Stale   int64 `json:"stale"`
+}
+
+type Provider struct {
+	next  services.WeatherAPIProvider
+	store Store
+	ttl   TTL
+	group singleflight.Group
+	now   func() time.Time
+
+	hits, misses, fetches, stale atomic.Int64
+}
+
+func New(next services.WeatherAPIProvider, store Store, ttl TTL) *Provider {
+	return &Provider{
+		next:  next,
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+func (p *Provider) Current(ctx context.Context, city string, opts ...weatherapi.Option) (*models.CurrentResponse, error) {
+	return fetch(ctx, p, key("current", city, opts), p.ttl.Current, func(ctx context.Context) (*models.CurrentResponse, error) {
+		return p.next.Current(ctx, city, opts...)
+	})
+}
+
+func (p *Provider) Forecast(ctx context.Context, city string, days int, opts ...weatherapi.Option) (*models.ForecastResponse, error) {
+	return fetch(ctx, p, key(fmt.Sprintf("forecast/%d", days), city, opts), p.ttl.Forecast, func(ctx context.Context) (*models.ForecastResponse, error) {
+		return p.next.Forecast(ctx, city, days, opts...)
+	})
+}
+
+func (p *Provider) Alerts(ctx context.Context, city string) (*models.AlertsResponse, error) {
+	return fetch(ctx, p, key("alerts", city, nil), p.ttl.Alerts, func(ctx context.Context) (*models.AlertsResponse, error) {
+		return p.next.Alerts(ctx, city)
+	})
+}
+
+// History returns past weather if the wrapped provider serves it. Ranges
+// that ended everywhere, that is before yesterday's UTC date, are cached
+// without expiry. Later ranges use the forecast TTL because the last day
+// may still be under way west of UTC. A zero endDate asks for date alone.
+func (p *Provider) History(ctx context.Context, city string, date, endDate time.Time) (*models.HistoryResponse, error) {
+	history, ok := p.next.(services.WeatherHistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("weather history: %w", errors.ErrUnsupported)
+	}
+
+	last := date
+	if endDate.After(last) {
+		last = endDate
+	}
+
+	ttl := p.ttl.Forecast
+	if last.Before(today(p.now()).AddDate(0, 0, -1)) {
+		ttl = forever
+	}
+
+	endpoint := fmt.Sprintf("history/%s/%s", date.Format(time.DateOnly), endDate.Format(time.DateOnly))
+
+	return fetch(ctx, p, key(endpoint, city, nil), ttl, func(ctx context.Context) (*models.HistoryResponse, error) {
+		return history.History(ctx, city, date, endDate)
+	})
+}
+
+// Astronomy returns the sun and moon times if the wrapped provider serves
+// them. The times for a date do not change and are cached without expiry.
+func (p *Provider) Astronomy(ctx context.Context, city string, date time.Time) (*models.AstronomyResponse, error) {
+	astronomy, ok := p.next.(services.AstronomyProvider)
+	if !ok {
+		return nil, fmt.Errorf("astronomy: %w", errors.ErrUnsupported)
+	}
+
+	endpoint := "astronomy/" + date.Format(time.DateOnly)
+
+	return fetch(ctx, p, key(endpoint, city, nil), forever, func(ctx context.Context) (*models.AstronomyResponse, error) {
+		return astronomy.Astronomy(ctx, city, date)
+	})
+}
+
+// Marine returns the marine forecast if the wrapped provider serves it,
+// cached for as long as forecasts are.
+func (p *Provider) Marine(ctx context.Context, query string, days int) (*models.MarineResponse, error) {
+	marine, ok := p.next.(services.MarineProvider)
+	if !ok {
+		return nil, fmt.Errorf("marine forecast: %w", errors.ErrUnsupported)
+	}
+
+	return fetch(ctx, p, key(fmt.Sprintf("marine/%d", days), query, nil), p.ttl.Forecast, func(ctx context.Context) (*models.MarineResponse, error) {
+		return marine.Marine(ctx, query, days)
+	})
+}
+
+// Search returns the places matching query if the wrapped provider can
+// search for them.
+func (p *Provider) Search(ctx context.Context, query string) (models.SearchResponse, error) {
+	search, ok := p.next.(services.LocationSearcher)
+	if !ok {
+		return nil, fmt.Errorf("location search: %w", errors.ErrUnsupported)
+	}
+
+	places, err := fetch(ctx, p, key("search", query, nil), searchTTL, func(ctx context.Context) (*models.SearchResponse, error) {
+		places, err := search.Search(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return &places, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return *places, nil
+}
+
+// Stats returns how many requests were served from the cache, how many
+// missed it, how many upstream calls the misses needed after concurrent
+// identical requests were merged and how many were answered with expired
+// entries because the call budget ran low.
+func (p *Provider) Stats() Stats {
+	return Stats{
+		Hits:    p.hits.Load(),
+		Misses:  p.misses.Load(),
+		Fetches: p.fetches.Load(),
+		Stale:   p.stale.Load(),
+	}
+}
+
+// fetch serves the value from the store or calls upstream once for all
+// concurrent requests with the same key. Responses are stored encoded so
+// that callers never share a value and errors are never cached. When the
+// upstream call budget is low, an expired entry is better than nothing and
+// is served instead of the error.
+//
+// The shared call does not run on any one caller's context, so a caller
+// that gives up does not fail the others. It keeps the caller's values and
+// is bounded by fetchTimeout instead.
+func fetch[T any](ctx context.Context, p *Provider, key string, ttl time.Duration, call func(context.Context) (*T, error)) (*T, error) {
+	if ttl == 0 {
+		return call(ctx)
+	}
+
+	if data, ok := p.lookup(key); ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			p.hits.Add(1)
+			return &value, nil
+		}
+	}
+
+	p.misses.Add(1)
+
+	shared := p.group.DoChan(key, func() (any, error) {
+		// A request that missed while another was finishing the same fetch
+		// finds the fresh entry here instead of calling upstream again.
+		if data, ok := p.lookup(key); ok {
+			return data, nil
+		}
+
+		p.fetches.Add(1)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		value, err := call(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := Entry{Value: data}
+		if ttl != forever {
+			entry.ExpiresAt = p.now().Add(ttl)
+		}
+
+		p.store.Set(key, entry)
+
+		return data, nil
+	})
+
+	var (
+		data any
+		err  error
+	)
+
+	select {
+	case result := <-shared:
+		data, err = result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if errors.Is(err, weatherapi.ErrBudgetLow) {
+		if entry, ok := p.store.Get(key); ok {
+			data, err = entry.Value, nil
+			p.stale.Add(1)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var value T
+	if err := json.Unmarshal(data.([]byte), &value); err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// lookup returns the stored value for key unless it has expired.
+func (p *Provider) lookup(key string) ([]byte, bool) {
+	entry, ok := p.store.Get(key)
+	if !ok || entry.expired(p.now()) {
+		return nil, false
+	}
+
+	return entry.Value, true
+}
+
+// today returns midnight UTC of now's date. History dates are local to
+// the location, which may be up to a day behind it.
+func today(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// key normalises the city so that spellings of the same place share an
+// entry: names are lower-cased and coordinates are rounded to two decimal
+// places (about 1 km).
+func key(endpoint, city string, opts []weatherapi.Option) string {
+	place := strings.Join(strings.Fields(strings.ToLower(city)), " ")
+	if lat, lon, ok := weatherapi.ParseCoordinates(city); ok {
+		place = fmt.Sprintf("%.2f,%.2f", lat, lon)
+	}
+
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	return endpoint + ":" + place + "?" + query.Encode()
+}
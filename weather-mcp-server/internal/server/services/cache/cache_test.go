@@ -0,0 +1,487 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func currentResponse(name string) *models.CurrentResponse {
+	return &models.CurrentResponse{
+		Location: models.Location{Name: name},
+		Current:  models.Current{TempC: 18.4},
+	}
+}
+
+func TestCurrentCache(t *testing.T) {
+	testCases := map[string]struct {
+		first, second string
+		ttl           time.Duration
+		calls         int
+		wait          Stats
+	}{
+		"same_city_is_cached": {
+			first:  "London",
+			second: "London",
+			ttl:    time.Minute,
+			calls:  1,
+			wait:   Stats{Hits: 1, Misses: 1, Fetches: 1},
+		},
+		"city_is_case_and_space_insensitive": {
+			first:  "London",
+			second: "  lONDON ",
+			ttl:    time.Minute,
+			calls:  1,
+			wait:   Stats{Hits: 1, Misses: 1, Fetches: 1},
+		},
+		"coordinates_are_rounded": {
+			first:  "51.5072,-0.1276",
+			second: "51.51, -0.13",
+			ttl:    time.Minute,
+			calls:  1,
+			wait:   Stats{Hits: 1, Misses: 1, Fetches: 1},
+		},
+		"different_cities": {
+			first:  "London",
+			second: "Paris",
+			ttl:    time.Minute,
+			calls:  2,
+			wait:   Stats{Misses: 2, Fetches: 2},
+		},
+		"zero_ttl_disables_cache": {
+			first:  "London",
+			second: "London",
+			calls:  2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+			weatherAPI.EXPECT().
+				Current(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+					return currentResponse(city), nil
+				}).
+				Times(tc.calls)
+
+			provider := New(weatherAPI, NewMemoryStore(), TTL{Current: tc.ttl})
+
+			first, err := provider.Current(context.Background(), tc.first)
+			require.NoError(t, err)
+
+			second, err := provider.Current(context.Background(), tc.second)
+			require.NoError(t, err)
+
+			if tc.calls == 1 {
+				assert.Equal(t, first, second)
+				assert.NotSame(t, first, second)
+			}
+
+			assert.Equal(t, tc.wait, provider.Stats())
+		})
+	}
+}
+
+func TestOptionsAreKeyed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		Return(currentResponse("London"), nil).
+		Times(1)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London", gomock.Any()).
+		Return(currentResponse("London"), nil).
+		Times(1)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Current: time.Minute})
+
+	_, err := provider.Current(context.Background(), "London")
+	require.NoError(t, err)
+
+	_, err = provider.Current(context.Background(), "London", weatherapi.WithAirQuality())
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{Misses: 2, Fetches: 2}, provider.Stats())
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	gomock.InOrder(
+		weatherAPI.EXPECT().
+			Alerts(gomock.Any(), "London").
+			Return(nil, errors.New("weather API not available. Code: 500")),
+		weatherAPI.EXPECT().
+			Alerts(gomock.Any(), "London").
+			Return(&models.AlertsResponse{}, nil),
+	)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Alerts: time.Minute})
+
+	_, err := provider.Alerts(context.Background(), "London")
+	assert.EqualError(t, err, "weather API not available. Code: 500")
+
+	_, err = provider.Alerts(context.Background(), "London")
+	assert.NoError(t, err)
+}
+
+func TestEntriesExpire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Forecast(gomock.Any(), "London", 3).
+		Return(&models.ForecastResponse{}, nil).
+		Times(2)
+
+	now := time.Date(2025, 4, 11, 12, 0, 0, 0, time.UTC)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Forecast: time.Hour})
+	provider.now = func() time.Time { return now }
+
+	for _, elapsed := range []time.Duration{0, 59 * time.Minute, time.Hour} {
+		now = now.Add(elapsed)
+
+		_, err := provider.Forecast(context.Background(), "London", 3)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 2, Fetches: 2}, provider.Stats())
+}
+
+func TestConcurrentRequestsAreMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		DoAndReturn(func(_ context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+			<-release
+			return currentResponse(city), nil
+		}).
+		Times(1)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Current: time.Minute})
+
+	const requests = 5
+
+	var wg sync.WaitGroup
+
+	for range requests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := provider.Current(context.Background(), "London")
+			assert.NoError(t, err)
+			assert.Equal(t, currentResponse("London"), result)
+		}()
+	}
+
+	// Let every request miss the cache before the upstream call returns.
+	require.Eventually(t, func() bool {
+		return provider.Stats().Misses == requests
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, Stats{Misses: requests, Fetches: 1}, provider.Stats())
+}
+
+func TestCancelledCallerDoesNotFailOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		DoAndReturn(func(ctx context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+			close(started)
+
+			select {
+			case <-release:
+				return currentResponse(city), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}).
+		Times(1)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Current: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan error)
+
+	go func() {
+		_, err := provider.Current(ctx, "London")
+		first <- err
+	}()
+
+	<-started
+
+	second := make(chan *models.CurrentResponse)
+
+	go func() {
+		result, err := provider.Current(context.Background(), "London")
+		assert.NoError(t, err)
+		second <- result
+	}()
+
+	require.Eventually(t, func() bool {
+		return provider.Stats().Misses == 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.Equal(t, currentResponse("London"), <-second)
+}
+
+type historyProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockWeatherHistoryProvider
+}
+
+func TestHistoryCache(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, 4, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := map[string]struct {
+		date, endDate time.Time
+		elapsed       time.Duration
+		calls         int
+	}{
+		"past_days_never_expire": {
+			date:    day(1),
+			endDate: day(7),
+			elapsed: 365 * 24 * time.Hour,
+			calls:   1,
+		},
+		"range_with_today_uses_forecast_ttl": {
+			date:    day(5),
+			endDate: day(11),
+			elapsed: 2 * time.Hour,
+			calls:   2,
+		},
+		"past_single_day_never_expires": {
+			date:    day(3),
+			elapsed: 365 * 24 * time.Hour,
+			calls:   1,
+		},
+		"yesterday_in_utc_uses_forecast_ttl": {
+			date:    day(10),
+			elapsed: 2 * time.Hour,
+			calls:   2,
+		},
+		"day_before_yesterday_never_expires": {
+			date:    day(9),
+			elapsed: 365 * 24 * time.Hour,
+			calls:   1,
+		},
+		"today_alone_uses_forecast_ttl": {
+			date:    day(11),
+			elapsed: 2 * time.Hour,
+			calls:   2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			history := mock.NewMockWeatherHistoryProvider(ctrl)
+			history.EXPECT().
+				History(gomock.Any(), "London", tc.date, tc.endDate).
+				Return(&models.HistoryResponse{Location: models.Location{Name: "London"}}, nil).
+				Times(tc.calls)
+
+			now := time.Date(2025, 4, 11, 12, 0, 0, 0, time.UTC)
+
+			provider := New(historyProvider{mock.NewMockWeatherAPIProvider(ctrl), history},
+				NewMemoryStore(), TTL{Forecast: time.Hour})
+			provider.now = func() time.Time { return now }
+
+			_, err := provider.History(context.Background(), "London", tc.date, tc.endDate)
+			require.NoError(t, err)
+
+			now = now.Add(tc.elapsed)
+
+			result, err := provider.History(context.Background(), "London", tc.date, tc.endDate)
+			require.NoError(t, err)
+			assert.Equal(t, "London", result.Location.Name)
+		})
+	}
+}
+
+func TestHistoryUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := New(mock.NewMockWeatherAPIProvider(ctrl), NewMemoryStore(), TTL{})
+
+	_, err := provider.History(context.Background(), "London", time.Now(), time.Now())
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+type astronomyProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockAstronomyProvider
+}
+
+func TestAstronomyCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	response := &models.AstronomyResponse{Location: models.Location{Name: "London"}}
+
+	astronomy := mock.NewMockAstronomyProvider(ctrl)
+	astronomy.EXPECT().
+		Astronomy(gomock.Any(), "London", date).
+		Return(response, nil).
+		Times(1)
+
+	provider := New(astronomyProvider{mock.NewMockWeatherAPIProvider(ctrl), astronomy}, NewMemoryStore(), TTL{})
+
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	for _, elapsed := range []time.Duration{0, 365 * 24 * time.Hour} {
+		now = now.Add(elapsed)
+
+		result, err := provider.Astronomy(context.Background(), "London", date)
+		require.NoError(t, err)
+		assert.Equal(t, response, result)
+	}
+
+	_, err := New(mock.NewMockWeatherAPIProvider(ctrl), NewMemoryStore(), TTL{}).
+		Astronomy(context.Background(), "London", date)
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+type marineProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockMarineProvider
+}
+
+func TestMarineCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	response := &models.MarineResponse{Location: models.Location{Name: "Brighton"}}
+
+	marine := mock.NewMockMarineProvider(ctrl)
+	marine.EXPECT().
+		Marine(gomock.Any(), "Brighton", 3).
+		Return(response, nil).
+		Times(1)
+
+	provider := New(marineProvider{mock.NewMockWeatherAPIProvider(ctrl), marine}, NewMemoryStore(), TTL{Forecast: time.Hour})
+
+	for _, query := range []string{"Brighton", "brighton"} {
+		result, err := provider.Marine(context.Background(), query, 3)
+		require.NoError(t, err)
+		assert.Equal(t, response, result)
+	}
+
+	_, err := New(mock.NewMockWeatherAPIProvider(ctrl), NewMemoryStore(), TTL{}).
+		Marine(context.Background(), "Brighton", 3)
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+type searchProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockLocationSearcher
+}
+
+func TestSearchCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	places := models.SearchResponse{{ID: 2618724, Name: "Springfield", Region: "Illinois"}}
+
+	searcher := mock.NewMockLocationSearcher(ctrl)
+	searcher.EXPECT().
+		Search(gomock.Any(), "Springfield").
+		Return(places, nil).
+		Times(1)
+
+	provider := New(searchProvider{mock.NewMockWeatherAPIProvider(ctrl), searcher}, NewMemoryStore(), TTL{})
+
+	for _, query := range []string{"Springfield", "springfield"} {
+		result, err := provider.Search(context.Background(), query)
+		require.NoError(t, err)
+		assert.Equal(t, places, result)
+	}
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Fetches: 1}, provider.Stats())
+}
+
+func TestStaleEntriesWhenBudgetIsLow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	gomock.InOrder(
+		weatherAPI.EXPECT().
+			Current(gomock.Any(), "London").
+			Return(currentResponse("London"), nil),
+		weatherAPI.EXPECT().
+			Current(gomock.Any(), gomock.Any()).
+			Return(nil, weatherapi.ErrBudgetLow).
+			Times(2),
+	)
+
+	now := time.Date(2025, 4, 11, 12, 0, 0, 0, time.UTC)
+
+	provider := New(weatherAPI, NewMemoryStore(), TTL{Current: time.Minute})
+	provider.now = func() time.Time { return now }
+
+	_, err := provider.Current(context.Background(), "London")
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+
+	result, err := provider.Current(context.Background(), "London")
+	require.NoError(t, err)
+	assert.Equal(t, currentResponse("London"), result)
+
+	_, err = provider.Current(context.Background(), "Paris")
+	assert.ErrorIs(t, err, weatherapi.ErrBudgetLow)
+
+	assert.Equal(t, Stats{Misses: 3, Fetches: 3, Stale: 1}, provider.Stats())
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Entry is an encoded response with the time it stops being fresh.
+// A zero ExpiresAt never expires.
+type Entry struct {
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (e Entry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Store keeps cache entries. Expiry is checked by the caller, so a store
+// may return stale entries.
+type Store interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry)
+}
+
+// maxMemoryEntries bounds the memory store. Expired entries are kept to
+// answer from while the call budget is low, so without a bound the store
+// would only grow.
+const maxMemoryEntries = 10000
+
+// MemoryStore keeps the most recently used entries in memory, evicting the
+// least recently used one when it is full.
+type MemoryStore struct {
+	mu      sync.Mutex
+	limit   int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key   string
+	entry Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return newMemoryStore(maxMemoryEntries)
+}
+
+func newMemoryStore(limit int) *MemoryStore {
+	return &MemoryStore{
+		limit:   limit,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryStore) Get(key string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+
+	s.order.MoveToFront(element)
+
+	return element.Value.(*memoryEntry).entry, true
+}
+
+func (s *MemoryStore) Set(key string, entry Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*memoryEntry).entry = entry
+		s.order.MoveToFront(element)
+
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, entry: entry})
+
+	if s.order.Len() > s.limit {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of entries held.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+type layeredStore struct {
+	memory, disk Store
+}
+
+// Layered reads through memory to disk, copying disk hits into memory,
+// and writes to both.
+func Layered(memory, disk Store) Store {
+	return &layeredStore{
+		memory: memory,
+		disk:   disk,
+	}
+}
+
+func (s *layeredStore) Get(key string) (Entry, bool) {
+	if e, ok := s.memory.Get(key); ok {
+		return e, true
+	}
+
+	e, ok := s.disk.Get(key)
+	if ok {
+		s.memory.Set(key, e)
+	}
+
+	return e, ok
+}
+
+func (s *layeredStore) Set(key string, entry Entry) {
+	s.memory.Set(key, entry)
+	s.disk.Set(key, entry)
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayeredStore(t *testing.T) {
+	memory, disk := NewMemoryStore(), NewMemoryStore()
+
+	disk.Set("forecast:london", Entry{Value: []byte(`{}`)})
+
+	store := Layered(memory, disk)
+
+	entry, ok := store.Get("forecast:london")
+	require.True(t, ok)
+	assert.Equal(t, []byte(`{}`), entry.Value)
+
+	_, ok = memory.Get("forecast:london")
+	assert.True(t, ok, "disk hits are copied into memory")
+
+	store.Set("current:paris", Entry{Value: []byte(`[]`)})
+
+	_, ok = disk.Get("current:paris")
+	assert.True(t, ok, "writes reach the disk")
+
+	_, ok = store.Get("alerts:tokyo")
+	assert.False(t, ok)
+}
+
+func TestMemoryStoreEviction(t *testing.T) {
+	store := newMemoryStore(2)
+
+	store.Set("current:london", Entry{Value: []byte(`1`)})
+	store.Set("current:paris", Entry{Value: []byte(`2`)})
+
+	_, ok := store.Get("current:london")
+	require.True(t, ok)
+
+	store.Set("current:tokyo", Entry{Value: []byte(`3`)})
+
+	assert.Equal(t, 2, store.Len())
+
+	_, ok = store.Get("current:paris")
+	assert.False(t, ok, "the least recently used entry is evicted")
+
+	_, ok = store.Get("current:london")
+	assert.True(t, ok, "reading an entry keeps it")
+
+	store.Set("current:london", Entry{Value: []byte(`4`)})
+
+	entry, ok := store.Get("current:london")
+	require.True(t, ok)
+	assert.Equal(t, []byte(`4`), entry.Value)
+	assert.Equal(t, 2, store.Len(), "replacing an entry does not grow the store")
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	store, err := OpenBoltStore(path)
+	require.NoError(t, err)
+
+	fresh := Entry{Value: []byte(`{"name":"London"}`), ExpiresAt: time.Now().Add(time.Hour).Round(0)}
+
+	store.Set("fresh", fresh)
+	store.Set("forever", Entry{Value: []byte(`{}`)})
+	store.Set("stale", Entry{Value: []byte(`{}`), ExpiresAt: time.Now().Add(-time.Minute)})
+	store.Set("ancient", Entry{Value: []byte(`{}`), ExpiresAt: time.Now().Add(-staleLimit - time.Hour)})
+
+	require.NoError(t, store.Close())
+
+	store, err = OpenBoltStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	entry, ok := store.Get("fresh")
+	require.True(t, ok, "entries survive reopening")
+	assert.Equal(t, fresh.Value, entry.Value)
+	assert.True(t, fresh.ExpiresAt.Equal(entry.ExpiresAt))
+
+	_, ok = store.Get("forever")
+	assert.True(t, ok)
+
+	_, ok = store.Get("stale")
+	assert.True(t, ok, "recently expired entries are kept to serve while the budget is low")
+
+	_, ok = store.Get("ancient")
+	assert.False(t, ok, "entries expired beyond the stale limit are dropped on open")
+}
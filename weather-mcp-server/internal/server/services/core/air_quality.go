@@ -0,0 +1,47 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/airquality"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+func (ws *WeatherService) AirQuality(ctx context.Context, city string) (string, error) {
+	data, err := ws.weatherAPI.Current(ctx, city, weatherapi.WithAirQuality())
+	if err != nil {
+		return "", ws.locationError(ctx, city, err)
+	}
+
+	if data.Current.AirQuality == nil {
+		return "", services.ErrNoAirQuality
+	}
+
+	aq := *data.Current.AirQuality
+	assessment := airquality.Assess(aq)
+
+	var buf bytes.Buffer
+
+	if err := ws.renderer.ExecuteTemplate(&buf, "air_quality.html", map[string]interface{}{
+		"Location":  fmt.Sprintf("%s, %s", data.Location.Name, data.Location.Country),
+		"Index":     fmt.Sprintf("%d", assessment.Index),
+		"Category":  assessment.Category.String(),
+		"Level":     fmt.Sprintf("%d", assessment.Category),
+		"Advice":    assessment.Category.Advice(),
+		"Dominant":  assessment.Dominant,
+		"DEFRABand": assessment.DEFRABand,
+		"PM25":      fmt.Sprintf("%.1f", aq.PM25),
+		"PM10":      fmt.Sprintf("%.1f", aq.PM10),
+		"O3":        fmt.Sprintf("%.1f", aq.O3),
+		"NO2":       fmt.Sprintf("%.1f", aq.NO2),
+		"SO2":       fmt.Sprintf("%.1f", aq.SO2),
+		"CO":        fmt.Sprintf("%.1f", aq.CO),
+	}); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
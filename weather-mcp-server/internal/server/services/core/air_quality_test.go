@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func TestAirQuality(t *testing.T) {
+	testCases := map[string]struct {
+		city            string
+		errString       string
+		wait            string
+		setupWeatherAPI func(weatherAPI *mock.MockWeatherAPIProvider)
+	}{
+		"missing_air_quality": {
+			city:      "Tokyo",
+			errString: "air quality data is not available for this location",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Current(context.Background(), "Tokyo", gomock.Any()).
+					Return(&models.CurrentResponse{}, nil)
+			},
+		},
+		"successful_result": {
+			city: "London",
+			wait: "London, United Kingdom 83 Moderate PM10 Low 5.2 120.0",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Current(context.Background(), "London", gomock.Any()).
+					Return(&models.CurrentResponse{
+						Location: models.Location{
+							Name:    "London",
+							Country: "United Kingdom",
+						},
+						Current: models.Current{
+							AirQuality: &models.AirQuality{
+								PM25:         5.2,
+								PM10:         120,
+								USEPAIndex:   1,
+								GBDEFRAIndex: 2,
+							},
+						},
+					}, nil)
+			},
+		},
+	}
+
+	renderer, err := template.New("air_quality.html").Parse(
+		"{{ .Location }} {{ .Index }} {{ .Category }} {{ .Dominant }} " +
+			"{{ .DEFRABand }} {{ .PM25 }} {{ .PM10 }}")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+
+	svc := New(renderer, weatherAPI, nil, nil)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherAPI != nil {
+				tc.setupWeatherAPI(weatherAPI)
+			}
+
+			data, err := svc.Weather().AirQuality(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				assert.ErrorIs(t, err, services.ErrNoAirQuality)
+			}
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type alertsReport struct {
+	Location string         `json:"location"`
+	Count    int            `json:"count"`
+	Alerts   []models.Alert `json:"alerts"`
+}
+
+func (ws *WeatherService) Alerts(ctx context.Context, city string) (*services.StructuredResult, error) {
+	data, err := ws.weatherAPI.Alerts(ctx, city)
+	if err != nil {
+		return nil, ws.locationError(ctx, city, err)
+	}
+
+	report := alertsReport{
+		Location: fmt.Sprintf("%s, %s", data.Location.Name, data.Location.Country),
+		Count:    len(data.Alerts.Alert),
+		Alerts:   data.Alerts.Alert,
+	}
+
+	if report.Alerts == nil {
+		report.Alerts = []models.Alert{}
+	}
+
+	structured, err := marshalJSON(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.StructuredResult{
+		Summary: alertsSummary(report),
+		JSON:    structured,
+	}, nil
+}
+
+func alertsSummary(report alertsReport) string {
+	if report.Count == 0 {
+		return fmt.Sprintf("✅ No active weather alerts for %s.", report.Location)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "⚠️ %d active weather alert(s) for %s:\n", report.Count, report.Location)
+
+	for i, alert := range report.Alerts {
+		fmt.Fprintf(&sb, "\n%d. %s\n", i+1, alert.Headline)
+		fmt.Fprintf(&sb, "   • Event: %s\n", alert.Event)
+		fmt.Fprintf(&sb, "   • Severity: %s, Urgency: %s\n", alert.Severity, alert.Urgency)
+		fmt.Fprintf(&sb, "   • Areas: %s\n", alert.Areas)
+		fmt.Fprintf(&sb, "   • Effective: %s, Expires: %s\n", alert.Effective, alert.Expires)
+
+		if alert.Instruction != "" {
+			fmt.Fprintf(&sb, "   • Instruction: %s\n", alert.Instruction)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func TestAlerts(t *testing.T) {
+	testCases := map[string]struct {
+		city            string
+		errString       string
+		wait            *services.StructuredResult
+		setupWeatherAPI func(weatherAPI *mock.MockWeatherAPIProvider)
+	}{
+		"city_not_found": {
+			city:      "Tokyo",
+			errString: "weather API not available. Code: 400",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Alerts(context.Background(), "Tokyo").
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"no_alerts": {
+			city: "Paris",
+			wait: &services.StructuredResult{
+				Summary: "✅ No active weather alerts for Paris, France.",
+				JSON:    `{"location":"Paris, France","count":0,"alerts":[]}`,
+			},
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Alerts(context.Background(), "Paris").
+					Return(&models.AlertsResponse{
+						Location: models.Location{
+							Name:    "Paris",
+							Country: "France",
+						},
+					}, nil)
+			},
+		},
+		"active_alerts": {
+			city: "London",
+			wait: &services.StructuredResult{
+				Summary: "⚠️ 1 active weather alert(s) for London, United Kingdom:\n\n" +
+					"1. Met Office: Yellow warning for rain\n" +
+					"   • Event: Yellow warning for rain\n" +
+					"   • Severity: Moderate, Urgency: Expected\n" +
+					"   • Areas: London & South East England\n" +
+					"   • Effective: 2025-04-11T09:00:00+00:00, Expires: 2025-04-11T21:00:00+00:00",
+				JSON: `{"location":"London, United Kingdom","count":1,"alerts":[{"headline":"Met Office: Yellow warning for rain",` +
+					`"msgtype":"","severity":"Moderate","urgency":"Expected","areas":"London & South East England",` +
+					`"category":"","certainty":"","event":"Yellow warning for rain","note":"",` +
+					`"effective":"2025-04-11T09:00:00+00:00","expires":"2025-04-11T21:00:00+00:00","desc":"","instruction":""}]}`,
+			},
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Alerts(context.Background(), "London").
+					Return(&models.AlertsResponse{
+						Location: models.Location{
+							Name:    "London",
+							Country: "United Kingdom",
+						},
+						Alerts: models.Alerts{
+							Alert: []models.Alert{
+								{
+									Headline:  "Met Office: Yellow warning for rain",
+									Severity:  "Moderate",
+									Urgency:   "Expected",
+									Areas:     "London & South East England",
+									Event:     "Yellow warning for rain",
+									Effective: "2025-04-11T09:00:00+00:00",
+									Expires:   "2025-04-11T21:00:00+00:00",
+								},
+							},
+						},
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+
+	svc := New(nil, weatherAPI, nil, nil)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherAPI != nil {
+				tc.setupWeatherAPI(weatherAPI)
+			}
+
+			data, err := svc.Weather().Alerts(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
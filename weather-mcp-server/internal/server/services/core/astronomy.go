@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/astro"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+const (
+	// dateLayout and clockLayout are how WeatherAPI writes local dates and
+	// sun and moon times.
+	dateLayout  = "2006-01-02"
+	clockLayout = "03:04 PM"
+)
+
+type astronomyDay struct {
+	Date             string `json:"date"`
+	Sunrise          string `json:"sunrise"`
+	Sunset           string `json:"sunset"`
+	Moonrise         string `json:"moonrise,omitempty"`
+	Moonset          string `json:"moonset,omitempty"`
+	MoonPhase        string `json:"moon_phase"`
+	MoonIllumination int64  `json:"moon_illumination"`
+	// DayLengthMinutes is nil when the provider's times do not both rise
+	// and set. Calculated days are 1440 under the midnight sun and 0 in
+	// polar night.
+	DayLengthMinutes *int `json:"day_length_minutes"`
+}
+
+type astronomyReport struct {
+	Location string `json:"location"`
+	TzID     string `json:"tz_id"`
+	// Calculated is set when the provider could not answer and the days
+	// were worked out offline, without moonrise and moonset.
+	Calculated bool           `json:"calculated,omitempty"`
+	Days       []astronomyDay `json:"days"`
+}
+
+// Astronomy reports the sun and moon for each day from date through
+// endDate. A zero date is today at the location, and a zero endDate the
+// same day as date. When the provider cannot answer, the sun and the moon
+// phase are calculated from the location's coordinates instead.
+func (ws *WeatherService) Astronomy(ctx context.Context, city string, date, endDate time.Time) (*services.StructuredResult, error) {
+	report, err := ws.providerAstronomy(ctx, city, date, endDate)
+	if weatherapi.ProviderUnavailable(err) {
+		if calculated, calcErr := ws.calculatedAstronomy(ctx, city, date, endDate); calcErr == nil {
+			report, err = calculated, nil
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	structured, err := marshalJSON(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.StructuredResult{
+		Summary: astronomySummary(report),
+		JSON:    structured,
+	}, nil
+}
+
+func (ws *WeatherService) providerAstronomy(ctx context.Context, city string, date, endDate time.Time) (astronomyReport, error) {
+	provider, ok := ws.weatherAPI.(services.AstronomyProvider)
+	if !ok {
+		return astronomyReport{}, fmt.Errorf("astronomy: %w", errors.ErrUnsupported)
+	}
+
+	fetch := func(date time.Time) (*models.AstronomyResponse, error) {
+		data, err := provider.Astronomy(ctx, city, date)
+		if err != nil {
+			return nil, ws.locationError(ctx, city, err)
+		}
+
+		return data, nil
+	}
+
+	today := date.IsZero()
+	if today {
+		date = ws.now().UTC().Truncate(24 * time.Hour)
+	}
+
+	first, err := fetch(date)
+	if err != nil {
+		return astronomyReport{}, err
+	}
+
+	// The day at the location may differ from the day in UTC, which is
+	// only known once the location is.
+	if local := localDate(first.Location, ws.now()); today && !local.Equal(date) {
+		if first, err = fetch(local); err != nil {
+			return astronomyReport{}, err
+		}
+
+		date = local
+	}
+
+	if endDate.IsZero() || endDate.Before(date) {
+		endDate = date
+	}
+
+	zone := timeZone(first.Location)
+
+	report := astronomyReport{
+		Location: fmt.Sprintf("%s, %s", first.Location.Name, first.Location.Country),
+		TzID:     first.Location.TzID,
+		Days:     []astronomyDay{newAstronomyDay(date, first.Astronomy.Astro, zone)},
+	}
+
+	for day := date.AddDate(0, 0, 1); !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		data, err := fetch(day)
+		if err != nil {
+			return astronomyReport{}, err
+		}
+
+		report.Days = append(report.Days, newAstronomyDay(day, data.Astronomy.Astro, zone))
+	}
+
+	return report, nil
+}
+
+// calculatedAstronomy works out the sun and the moon phase with the astro
+// package for the place found by locate. Moonrise and moonset are not
+// calculated.
+func (ws *WeatherService) calculatedAstronomy(ctx context.Context, city string, date, endDate time.Time) (astronomyReport, error) {
+	location, err := ws.locate(ctx, city)
+	if err != nil {
+		return astronomyReport{}, err
+	}
+
+	if date.IsZero() {
+		date = localDate(location, ws.now())
+	}
+
+	if endDate.IsZero() || endDate.Before(date) {
+		endDate = date
+	}
+
+	zone := timeZone(location)
+
+	report := astronomyReport{
+		Location:   fmt.Sprintf("%s, %s", location.Name, location.Country),
+		TzID:       zone.String(),
+		Calculated: true,
+	}
+
+	if location.Country == "" {
+		report.Location = location.Name
+	}
+
+	for day := date; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		report.Days = append(report.Days, calculatedAstronomyDay(day, location, zone))
+	}
+
+	return report, nil
+}
+
+// locate returns the coordinates of a place without asking for its
+// astronomy. Coordinates need no lookup. Any other place is looked up
+// through the current weather, so it is only found when that is cached or
+// another provider can answer; otherwise the lookup fails like the
+// astronomy did and nothing is calculated.
+func (ws *WeatherService) locate(ctx context.Context, city string) (models.Location, error) {
+	if lat, lon, ok := weatherapi.ParseCoordinates(city); ok {
+		return models.Location{Name: city, Lat: lat, Lon: lon}, nil
+	}
+
+	data, err := ws.weatherAPI.Current(ctx, city)
+	if err != nil {
+		return models.Location{}, err
+	}
+
+	return data.Location, nil
+}
+
+func calculatedAstronomyDay(date time.Time, location models.Location, zone *time.Location) astronomyDay {
+	year, month, d := date.Date()
+	noon := time.Date(year, month, d, 12, 0, 0, 0, zone)
+
+	sun := astro.SunOn(location.Lat, location.Lon, noon)
+	moon := astro.MoonAt(noon)
+
+	day := astronomyDay{
+		Date:             date.Format(dateLayout),
+		Sunrise:          sun.Sunrise.Format(clockLayout),
+		Sunset:           sun.Sunset.Format(clockLayout),
+		MoonPhase:        moon.Phase.String(),
+		MoonIllumination: int64(math.Round(moon.Illumination * 100)),
+	}
+
+	var minutes int
+
+	switch {
+	case sun.AlwaysUp:
+		day.Sunrise, day.Sunset = "No sunrise", "No sunset"
+		minutes = 24 * 60
+	case sun.AlwaysDown:
+		day.Sunrise, day.Sunset = "No sunrise", "No sunset"
+	default:
+		minutes = int(sun.DayLength().Minutes())
+	}
+
+	day.DayLengthMinutes = &minutes
+
+	return day
+}
+
+func newAstronomyDay(date time.Time, astro models.Astro, zone *time.Location) astronomyDay {
+	day := astronomyDay{
+		Date:             date.Format(dateLayout),
+		Sunrise:          astro.Sunrise,
+		Sunset:           astro.Sunset,
+		Moonrise:         astro.Moonrise,
+		Moonset:          astro.Moonset,
+		MoonPhase:        astro.MoonPhase,
+		MoonIllumination: astro.MoonIllumination,
+	}
+
+	if length, ok := dayLength(date, astro, zone); ok {
+		minutes := int(length.Minutes())
+		day.DayLengthMinutes = &minutes
+	}
+
+	return day
+}
+
+// dayLength returns the time from sunrise to sunset. The times are read in
+// the location's time zone, so that a clock change during the day counts.
+func dayLength(date time.Time, astro models.Astro, zone *time.Location) (time.Duration, bool) {
+	sunrise, err := localClock(date, astro.Sunrise, zone)
+	if err != nil {
+		return 0, false
+	}
+
+	sunset, err := localClock(date, astro.Sunset, zone)
+	if err != nil || !sunset.After(sunrise) {
+		return 0, false
+	}
+
+	return sunset.Sub(sunrise), true
+}
+
+func localClock(date time.Time, clock string, zone *time.Location) (time.Time, error) {
+	return time.ParseInLocation(dateLayout+" "+clockLayout, date.Format(dateLayout)+" "+clock, zone)
+}
+
+func astronomySummary(report astronomyReport) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🌅 Sun and moon for %s", report.Location)
+
+	switch {
+	case report.Calculated:
+		fmt.Fprintf(&sb, ", calculated offline, times in %s", report.TzID)
+	case report.TzID != "":
+		fmt.Fprintf(&sb, ", local times in %s", report.TzID)
+	}
+
+	sb.WriteString(":\n")
+
+	for _, day := range report.Days {
+		length := "no sunrise or sunset"
+		if day.DayLengthMinutes != nil {
+			length = fmt.Sprintf("%dh %02dm of daylight", *day.DayLengthMinutes/60, *day.DayLengthMinutes%60)
+		}
+
+		fmt.Fprintf(&sb, "\n%s\n", day.Date)
+		fmt.Fprintf(&sb, "   • Sun: rises %s, sets %s, %s\n", clockText(day.Sunrise), clockText(day.Sunset), length)
+
+		if report.Calculated {
+			fmt.Fprintf(&sb, "   • Moon: %s, %d%% illuminated\n", day.MoonPhase, day.MoonIllumination)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "   • Moon: rises %s, sets %s, %s, %d%% illuminated\n",
+			clockText(day.Moonrise), clockText(day.Moonset), day.MoonPhase, day.MoonIllumination)
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// clockText returns a sun or moon time, or "never" in place of WeatherAPI's
+// "No sunrise", "No moonset" and the like.
+func clockText(clock string) string {
+	if strings.HasPrefix(clock, "No ") {
+		return "never"
+	}
+
+	return clock
+}
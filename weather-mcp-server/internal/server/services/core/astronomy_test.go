@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/cache"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type astronomyProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockAstronomyProvider
+}
+
+func calendarDay(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAstronomy(t *testing.T) {
+	london := models.Location{Name: "London", Country: "United Kingdom", TzID: "Europe/London"}
+
+	midsummer := models.Astro{
+		Sunrise:          "04:43 AM",
+		Sunset:           "09:21 PM",
+		Moonrise:         "01:47 AM",
+		Moonset:          "06:38 PM",
+		MoonPhase:        "Waning Crescent",
+		MoonIllumination: 20,
+	}
+
+	testCases := map[string]struct {
+		city           string
+		date, endDate  time.Time
+		errString      string
+		wait           *services.StructuredResult
+		setupAstronomy func(provider *mock.MockAstronomyProvider)
+	}{
+		"city_not_found": {
+			city:      "London",
+			date:      calendarDay(2025, time.June, 21),
+			errString: "weather API not available. Code: 400",
+			setupAstronomy: func(provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "London", calendarDay(2025, time.June, 21)).
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"today_at_the_location": {
+			city: "London",
+			wait: &services.StructuredResult{
+				Summary: "🌅 Sun and moon for London, United Kingdom, local times in Europe/London:\n\n" +
+					"2025-06-22\n" +
+					"   • Sun: rises 04:43 AM, sets 09:21 PM, 16h 38m of daylight\n" +
+					"   • Moon: rises 01:47 AM, sets 06:38 PM, Waning Crescent, 20% illuminated",
+				JSON: `{"location":"London, United Kingdom","tz_id":"Europe/London","days":[` +
+					`{"date":"2025-06-22","sunrise":"04:43 AM","sunset":"09:21 PM","moonrise":"01:47 AM","moonset":"06:38 PM",` +
+					`"moon_phase":"Waning Crescent","moon_illumination":20,"day_length_minutes":998}]}`,
+			},
+			setupAstronomy: func(provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "London", calendarDay(2025, time.June, 21)).
+					Return(&models.AstronomyResponse{Location: london}, nil)
+				provider.EXPECT().
+					Astronomy(context.Background(), "London", calendarDay(2025, time.June, 22)).
+					Return(&models.AstronomyResponse{Location: london, Astronomy: models.Astronomy{Astro: midsummer}}, nil)
+			},
+		},
+		"polar_day": {
+			city:    "Tromso",
+			date:    calendarDay(2025, time.June, 21),
+			endDate: calendarDay(2025, time.June, 22),
+			wait: &services.StructuredResult{
+				Summary: "🌅 Sun and moon for Tromso, Norway:\n\n" +
+					"2025-06-21\n" +
+					"   • Sun: rises never, sets never, no sunrise or sunset\n" +
+					"   • Moon: rises 02:10 AM, sets 03:40 PM, Waning Crescent, 20% illuminated\n\n" +
+					"2025-06-22\n" +
+					"   • Sun: rises never, sets never, no sunrise or sunset\n" +
+					"   • Moon: rises never, sets 04:20 PM, Waning Crescent, 12% illuminated",
+				JSON: `{"location":"Tromso, Norway","tz_id":"","days":[` +
+					`{"date":"2025-06-21","sunrise":"No sunrise","sunset":"No sunset","moonrise":"02:10 AM","moonset":"03:40 PM",` +
+					`"moon_phase":"Waning Crescent","moon_illumination":20,"day_length_minutes":null},` +
+					`{"date":"2025-06-22","sunrise":"No sunrise","sunset":"No sunset","moonrise":"No moonrise","moonset":"04:20 PM",` +
+					`"moon_phase":"Waning Crescent","moon_illumination":12,"day_length_minutes":null}]}`,
+			},
+			setupAstronomy: func(provider *mock.MockAstronomyProvider) {
+				tromso := models.Location{Name: "Tromso", Country: "Norway"}
+
+				provider.EXPECT().
+					Astronomy(context.Background(), "Tromso", calendarDay(2025, time.June, 21)).
+					Return(&models.AstronomyResponse{Location: tromso, Astronomy: models.Astronomy{Astro: models.Astro{
+						Sunrise: "No sunrise", Sunset: "No sunset", Moonrise: "02:10 AM", Moonset: "03:40 PM",
+						MoonPhase: "Waning Crescent", MoonIllumination: 20,
+					}}}, nil)
+				provider.EXPECT().
+					Astronomy(context.Background(), "Tromso", calendarDay(2025, time.June, 22)).
+					Return(&models.AstronomyResponse{Location: tromso, Astronomy: models.Astronomy{Astro: models.Astro{
+						Sunrise: "No sunrise", Sunset: "No sunset", Moonrise: "No moonrise", Moonset: "04:20 PM",
+						MoonPhase: "Waning Crescent", MoonIllumination: 12,
+					}}}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mock.NewMockAstronomyProvider(ctrl)
+
+	svc := New(nil, astronomyProvider{mock.NewMockWeatherAPIProvider(ctrl), provider}, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.June, 21, 23, 30, 0, 0, time.UTC)
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupAstronomy != nil {
+				tc.setupAstronomy(provider)
+			}
+
+			data, err := svc.Weather().Astronomy(context.Background(), tc.city, tc.date, tc.endDate)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
+
+func TestDayLength(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	// The clocks go forward an hour at 02:00 on 30 March 2025 in Paris.
+	length, ok := dayLength(calendarDay(2025, time.March, 30), models.Astro{Sunrise: "01:30 AM", Sunset: "07:30 PM"}, paris)
+
+	assert.True(t, ok)
+	assert.Equal(t, 17*time.Hour, length)
+}
+
+func TestCalculatedAstronomy(t *testing.T) {
+	unavailable := &weatherapi.StatusError{API: "weather", StatusCode: 503}
+	notFound := &weatherapi.StatusError{API: "weather", StatusCode: 400, Code: 1006}
+
+	testCases := map[string]struct {
+		city            string
+		date, endDate   time.Time
+		errString       string
+		wait            *services.StructuredResult
+		setupWeatherAPI func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider)
+	}{
+		"provider_unavailable": {
+			city: "London",
+			wait: &services.StructuredResult{
+				Summary: "🌅 Sun and moon for London, United Kingdom, calculated offline, times in Europe/London:\n\n" +
+					"2025-06-22\n" +
+					"   • Sun: rises 04:43 AM, sets 09:21 PM, 16h 38m of daylight\n" +
+					"   • Moon: Waning Crescent, 12% illuminated",
+				JSON: `{"location":"London, United Kingdom","tz_id":"Europe/London","calculated":true,"days":[` +
+					`{"date":"2025-06-22","sunrise":"04:43 AM","sunset":"09:21 PM","moon_phase":"Waning Crescent",` +
+					`"moon_illumination":12,"day_length_minutes":998}]}`,
+			},
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "London", calendarDay(2025, time.June, 21)).
+					Return(nil, unavailable)
+				weatherAPI.EXPECT().
+					Current(context.Background(), "London").
+					Return(&models.CurrentResponse{Location: models.Location{
+						Name: "London", Country: "United Kingdom", Lat: 51.52, Lon: -0.11, TzID: "Europe/London",
+					}}, nil)
+			},
+		},
+		"coordinates_need_no_lookup": {
+			city:    "69.65,18.96",
+			date:    calendarDay(2025, time.June, 21),
+			endDate: calendarDay(2025, time.June, 22),
+			wait: &services.StructuredResult{
+				Summary: "🌅 Sun and moon for 69.65,18.96, calculated offline, times in UTC:\n\n" +
+					"2025-06-21\n" +
+					"   • Sun: rises never, sets never, 24h 00m of daylight\n" +
+					"   • Moon: Waning Crescent, 21% illuminated\n\n" +
+					"2025-06-22\n" +
+					"   • Sun: rises never, sets never, 24h 00m of daylight\n" +
+					"   • Moon: Waning Crescent, 12% illuminated",
+				JSON: `{"location":"69.65,18.96","tz_id":"UTC","calculated":true,"days":[` +
+					`{"date":"2025-06-21","sunrise":"No sunrise","sunset":"No sunset","moon_phase":"Waning Crescent",` +
+					`"moon_illumination":21,"day_length_minutes":1440},` +
+					`{"date":"2025-06-22","sunrise":"No sunrise","sunset":"No sunset","moon_phase":"Waning Crescent",` +
+					`"moon_illumination":12,"day_length_minutes":1440}]}`,
+			},
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "69.65,18.96", calendarDay(2025, time.June, 21)).
+					Return(nil, weatherapi.ErrBudgetLow)
+			},
+		},
+		"polar_night_has_no_daylight": {
+			city: "69.65,18.96",
+			date: calendarDay(2025, time.December, 21),
+			wait: &services.StructuredResult{
+				Summary: "🌅 Sun and moon for 69.65,18.96, calculated offline, times in UTC:\n\n" +
+					"2025-12-21\n" +
+					"   • Sun: rises never, sets never, 0h 00m of daylight\n" +
+					"   • Moon: New Moon, 2% illuminated",
+				JSON: `{"location":"69.65,18.96","tz_id":"UTC","calculated":true,"days":[` +
+					`{"date":"2025-12-21","sunrise":"No sunrise","sunset":"No sunset","moon_phase":"New Moon",` +
+					`"moon_illumination":2,"day_length_minutes":0}]}`,
+			},
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "69.65,18.96", calendarDay(2025, time.December, 21)).
+					Return(nil, weatherapi.ErrBudgetLow)
+			},
+		},
+		"unknown_location_is_not_calculated": {
+			city:      "Atlantis",
+			date:      calendarDay(2025, time.June, 21),
+			errString: "weather API not available. Code: 400 (1006: )",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "Atlantis", calendarDay(2025, time.June, 21)).
+					Return(nil, notFound)
+			},
+		},
+		"uncached_place_is_not_calculated": {
+			city:      "London",
+			date:      calendarDay(2025, time.June, 21),
+			errString: "weather API not available. Code: 503",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider, provider *mock.MockAstronomyProvider) {
+				provider.EXPECT().
+					Astronomy(context.Background(), "London", calendarDay(2025, time.June, 21)).
+					Return(nil, unavailable)
+				weatherAPI.EXPECT().
+					Current(context.Background(), "London").
+					Return(nil, unavailable)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	provider := mock.NewMockAstronomyProvider(ctrl)
+
+	svc := New(nil, astronomyProvider{weatherAPI, provider}, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.June, 21, 23, 30, 0, 0, time.UTC)
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherAPI != nil {
+				tc.setupWeatherAPI(weatherAPI, provider)
+			}
+
+			data, err := svc.Weather().Astronomy(context.Background(), tc.city, tc.date, tc.endDate)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
+
+func TestAstronomyUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(context.Background(), "London").
+		Return(nil, errors.New("weather API not available. Code: 503"))
+
+	svc := New(nil, weatherAPI, nil, nil)
+
+	_, err := svc.Weather().Astronomy(context.Background(), "London", time.Time{}, time.Time{})
+
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestCalculatedAstronomyCachedPlace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	provider := mock.NewMockAstronomyProvider(ctrl)
+
+	// The place is looked up once, for the current weather, and found in the
+	// cache when the provider is down for the astronomy.
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		Return(&models.CurrentResponse{Location: models.Location{
+			Name: "London", Country: "United Kingdom", Lat: 51.52, Lon: -0.11, TzID: "Europe/London",
+		}}, nil)
+	provider.EXPECT().
+		Astronomy(gomock.Any(), "London", calendarDay(2025, time.June, 21)).
+		Return(nil, &weatherapi.StatusError{API: "weather", StatusCode: 503})
+
+	cached := cache.New(astronomyProvider{weatherAPI, provider}, cache.NewMemoryStore(), cache.TTL{Current: time.Hour})
+
+	svc := New(nil, cached, nil, nil)
+
+	_, err := cached.Current(context.Background(), "London")
+	assert.NoError(t, err)
+
+	data, err := svc.Weather().Astronomy(context.Background(), "London", calendarDay(2025, time.June, 21), time.Time{})
+	assert.NoError(t, err)
+
+	assert.Contains(t, data.Summary, "Sun and moon for London, United Kingdom, calculated offline")
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// compareWorkers bounds how many locations of a comparison are fetched at
+// once, so that a long list does not burst the provider's rate limit.
+const compareWorkers = 4
+
+// comparison is the outcome for one location of a comparison: the weather,
+// or the error that kept it from being fetched with the message shown for it.
+type comparison struct {
+	query   string
+	data    *models.CurrentResponse
+	err     error
+	message string
+}
+
+// Compare fetches the current weather for each city and renders them side
+// by side. A city that fails is reported in its row rather than failing the
+// whole comparison.
+func (ws *WeatherService) Compare(ctx context.Context, cities []string, system units.Set, lang string, output services.Output) (*services.StructuredResult, error) {
+	messages := i18n.For(lang)
+
+	var opts []weatherapi.Option
+	if messages.Lang() != i18n.DefaultLanguage {
+		opts = append(opts, weatherapi.WithLanguage(messages.Lang()))
+	}
+
+	results := ws.fetchAll(ctx, cities, opts...)
+
+	// Once the request itself is cancelled every row would only repeat it.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The message is worked out once per failed row, since errorText logs
+	// server faults and both the view and the report show it.
+	for i := range results {
+		if results[i].err != nil {
+			results[i].message = errorText(results[i].err)
+		}
+	}
+
+	now := ws.now()
+
+	structured, err := marshalJSON(newComparisonReport(messages, system, results, now))
+	if err != nil {
+		return nil, err
+	}
+
+	view := newComparisonView(messages, system, results, now)
+
+	var summary string
+
+	switch output {
+	case services.OutputJSON:
+		summary = structured
+	case services.OutputMarkdown:
+		summary = view.markdown()
+	case services.OutputText:
+		summary = view.text()
+	default:
+		var buf bytes.Buffer
+
+		if err := ws.renderer.ExecuteTemplate(&buf, "compare.html", view); err != nil {
+			return nil, err
+		}
+
+		summary = buf.String()
+	}
+
+	return &services.StructuredResult{
+		Summary: summary,
+		JSON:    structured,
+	}, nil
+}
+
+// fetchAll gets the current weather for every city with at most
+// compareWorkers requests in flight. The results are in the order of
+// cities.
+func (ws *WeatherService) fetchAll(ctx context.Context, cities []string, opts ...weatherapi.Option) []comparison {
+	results := make([]comparison, len(cities))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(compareWorkers, len(cities)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				data, err := ws.weatherAPI.Current(ctx, cities[i], opts...)
+				if err != nil {
+					err = ws.locationError(ctx, cities[i], err)
+				}
+
+				results[i] = comparison{query: cities[i], data: data, err: err}
+			}
+		}()
+	}
+
+	for i := range cities {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// errorText returns the message for a location that could not be fetched.
+// A server fault is logged and shown with the generic message.
+func errorText(err error) string {
+	if message, ok := services.ErrorMessage(err); ok {
+		return message
+	}
+
+	log.Printf("compare: %v", err)
+
+	return services.GenericErrorMessage
+}
+
+// comparisonRow is one location of a comparison as shown to people. Error
+// is set instead of the measurements when the location failed.
+type comparisonRow struct {
+	Location    string
+	Icon        string
+	Condition   string
+	Temperature string
+	FeelsLike   string
+	Humidity    string
+	WindSpeed   string
+	Pressure    string
+	LocalTime   string
+	Error       string
+}
+
+// comparisonView is a comparison as shown to people, in the HTML, Markdown
+// and text outputs.
+type comparisonView struct {
+	Lang     string
+	Messages *i18n.Catalog
+	Rows     []comparisonRow
+}
+
+func newComparisonView(messages *i18n.Catalog, system units.Set, results []comparison, now time.Time) *comparisonView {
+	view := &comparisonView{
+		Lang:     messages.Lang(),
+		Messages: messages,
+		Rows:     make([]comparisonRow, 0, len(results)),
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			view.Rows = append(view.Rows, comparisonRow{Location: result.query, Error: result.message})
+			continue
+		}
+
+		data := result.data
+		condition := currentCondition(data, now)
+
+		view.Rows = append(view.Rows, comparisonRow{
+			Location:    fmt.Sprintf("%s, %s", data.Location.Name, data.Location.Country),
+			Icon:        "https:" + condition.Icon,
+			Condition:   condition.Text,
+			Temperature: system.FormatTemperature(data.Current.TempC),
+			FeelsLike:   system.FormatTemperature(data.Current.FeelslikeC),
+			Humidity:    reading(&data.Current, models.FieldHumidity, fmt.Sprintf("%d%%", data.Current.Humidity)),
+			WindSpeed:   reading(&data.Current, models.FieldWindKph, system.FormatWind(data.Current.WindKph)),
+			Pressure:    reading(&data.Current, models.FieldPressureMb, system.FormatPressure(data.Current.PressureMb)),
+			LocalTime:   newObservation(data, now).local.Format("15:04 MST"),
+		})
+	}
+
+	return view
+}
+
+// labels returns the column headings in the order of cells.
+func (v *comparisonView) labels() []string {
+	return []string{
+		v.Messages.Text("label.location"),
+		v.Messages.Text("label.condition"),
+		v.Messages.Text("label.temperature"),
+		v.Messages.Text("label.feels_like"),
+		v.Messages.Text("label.humidity"),
+		v.Messages.Text("label.wind_speed"),
+		v.Messages.Text("label.pressure"),
+		v.Messages.Text("label.local_time"),
+	}
+}
+
+func (r comparisonRow) cells() []string {
+	return []string{r.Location, r.Condition, r.Temperature, r.FeelsLike, r.Humidity, r.WindSpeed, r.Pressure, r.LocalTime}
+}
+
+// tableCell escapes text for a Markdown table cell, where a pipe would end
+// the cell and a line break the row.
+var tableCell = strings.NewReplacer("|", "\\|", "\n", " ")
+
+func (v *comparisonView) markdown() string {
+	var sb strings.Builder
+
+	labels := v.labels()
+
+	fmt.Fprintf(&sb, "## %s\n\n", v.Messages.Text("compare.title"))
+	fmt.Fprintf(&sb, "| %s |\n", strings.Join(labels, " | "))
+	fmt.Fprintf(&sb, "|%s\n", strings.Repeat(" --- |", len(labels)))
+
+	for _, row := range v.Rows {
+		cells := row.cells()
+
+		if row.Error != "" {
+			cells = make([]string, len(labels))
+			cells[0], cells[1] = row.Location, "⚠️ "+row.Error
+		}
+
+		for i, cell := range cells {
+			cells[i] = tableCell.Replace(cell)
+		}
+
+		fmt.Fprintf(&sb, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (v *comparisonView) text() string {
+	var sb strings.Builder
+
+	labels := v.labels()
+
+	fmt.Fprintf(&sb, "%s\n", v.Messages.Text("compare.title"))
+
+	for _, row := range v.Rows {
+		fmt.Fprintf(&sb, "\n%s\n", row.Location)
+
+		if row.Error != "" {
+			fmt.Fprintf(&sb, "  ⚠️ %s\n", row.Error)
+			continue
+		}
+
+		for i, cell := range row.cells()[1:] {
+			fmt.Fprintf(&sb, "  %s: %s\n", labels[i+1], cell)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// comparisonReport is the structured comparison. Each location that was
+// fetched holds the same report as current_weather, following the schema at
+// schema.CurrentWeatherURI.
+type comparisonReport struct {
+	Lang      string             `json:"lang"`
+	Locations []comparedLocation `json:"locations"`
+}
+
+type comparedLocation struct {
+	Query   string         `json:"query"`
+	Weather *currentReport `json:"weather,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func newComparisonReport(messages *i18n.Catalog, system units.Set, results []comparison, now time.Time) comparisonReport {
+	report := comparisonReport{
+		Lang:      messages.Lang(),
+		Locations: make([]comparedLocation, 0, len(results)),
+	}
+
+	for _, result := range results {
+		location := comparedLocation{Query: result.query}
+
+		if result.err != nil {
+			location.Error = result.message
+		} else {
+			weather := newCurrentReport(messages, system, result.data, now)
+			location.Weather = &weather
+		}
+
+		report.Locations = append(report.Locations, location)
+	}
+
+	return report
+}
@@ -0,0 +1,272 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := map[string]struct {
+		output services.Output
+		wait   string
+	}{
+		"markdown": {
+			output: services.OutputMarkdown,
+			wait: "## 🌍 Weather comparison\n\n" +
+				"| Location | Condition | Temperature | Feels Like | Humidity | Wind Speed | Pressure | Local Time |\n" +
+				"| --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+				"| London, United Kingdom | Sunny | 18°C | 17°C | 45% | 4 km/h | 1022 mb | 13:07 BST |\n" +
+				"| Atlantis | ⚠️ No location matches \"Atlantis\", check the spelling or try coordinates |  |  |  |  |  |  |\n" +
+				"| Tokyo, Japan | Light rain | 12°C | 10°C | 88% | 15 km/h | 1008 mb | 21:07 JST |\n" +
+				"| Lima | ⚠️ The weather API is temporarily unavailable, try again later |  |  |  |  |  |  |",
+		},
+		"text": {
+			output: services.OutputText,
+			wait: "🌍 Weather comparison\n\n" +
+				"London, United Kingdom\n" +
+				"  Condition: Sunny\n" +
+				"  Temperature: 18°C\n" +
+				"  Feels Like: 17°C\n" +
+				"  Humidity: 45%\n" +
+				"  Wind Speed: 4 km/h\n" +
+				"  Pressure: 1022 mb\n" +
+				"  Local Time: 13:07 BST\n\n" +
+				"Atlantis\n" +
+				"  ⚠️ No location matches \"Atlantis\", check the spelling or try coordinates\n\n" +
+				"Tokyo, Japan\n" +
+				"  Condition: Light rain\n" +
+				"  Temperature: 12°C\n" +
+				"  Feels Like: 10°C\n" +
+				"  Humidity: 88%\n" +
+				"  Wind Speed: 15 km/h\n" +
+				"  Pressure: 1008 mb\n" +
+				"  Local Time: 21:07 JST\n\n" +
+				"Lima\n" +
+				"  ⚠️ The weather API is temporarily unavailable, try again later",
+		},
+		"html": {
+			output: services.OutputHTML,
+			wait: "London, United Kingdom: Sunny 18°C|" +
+				"Atlantis: No location matches &#34;Atlantis&#34;, check the spelling or try coordinates|" +
+				"Tokyo, Japan: Light rain 12°C|" +
+				"Lima: The weather API is temporarily unavailable, try again later|",
+		},
+	}
+
+	renderer, err := template.New("compare.html").Parse(
+		"{{ range .Rows }}{{ .Location }}: {{ with .Error }}{{ . }}{{ else }}{{ .Condition }} {{ .Temperature }}{{ end }}|{{ end }}")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		Return(&models.CurrentResponse{
+			Location: models.Location{Name: "London", Country: "United Kingdom", TzID: "Europe/London"},
+			Current: models.Current{
+				TempC:      18.4,
+				FeelslikeC: 17,
+				WindKph:    4.2,
+				Humidity:   45,
+				PressureMb: 1022,
+				Condition: models.Condition{
+					Text: "Sunny",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+					Code: 1000,
+				},
+			},
+		}, nil).
+		AnyTimes()
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "Atlantis").
+		Return(nil, &weatherapi.LocationError{Query: "Atlantis"}).
+		AnyTimes()
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "Tokyo").
+		Return(&models.CurrentResponse{
+			Location: models.Location{Name: "Tokyo", Country: "Japan", TzID: "Asia/Tokyo"},
+			Current: models.Current{
+				TempC:      12.2,
+				FeelslikeC: 10.1,
+				WindKph:    15.1,
+				Humidity:   88,
+				PressureMb: 1008,
+				Condition: models.Condition{
+					Text: "Light rain",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/296.png",
+					Code: 1183,
+				},
+			},
+		}, nil).
+		AnyTimes()
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "Lima").
+		Return(nil, fmt.Errorf("get current: %w", weatherapi.ErrUnavailable)).
+		AnyTimes()
+
+	svc := New(renderer, weatherAPI, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.April, 11, 12, 7, 27, 0, time.UTC)
+	}
+
+	cities := []string{"London", "Atlantis", "Tokyo", "Lima"}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, err := svc.Weather().Compare(context.Background(), cities, units.Metric, "en", tc.output)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wait, data.Summary)
+
+			var report comparisonReport
+
+			require.NoError(t, json.Unmarshal([]byte(data.JSON), &report))
+			require.Len(t, report.Locations, len(cities))
+
+			for i, location := range report.Locations {
+				assert.Equal(t, cities[i], location.Query)
+				assert.Equal(t, location.Error != "", location.Weather == nil)
+			}
+
+			assert.Equal(t, "Europe/London", report.Locations[0].Weather.Location.TzID)
+			assert.Equal(t, "The weather API is temporarily unavailable, try again later", report.Locations[3].Error)
+		})
+	}
+}
+
+func TestCompareMarkdownCells(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "Paris").
+		Return(&models.CurrentResponse{
+			Location: models.Location{Name: "Paris", Country: "France", TzID: "Europe/Paris"},
+			Current: models.Current{
+				TempC:      15.2,
+				FeelslikeC: 14.1,
+				WindKph:    9.8,
+				Humidity:   60,
+				PressureMb: 1015,
+				Condition:  models.Condition{Text: "Sun | showers", Code: 1063},
+			},
+		}, nil)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "Lyon|Nice").
+		Return(nil, errors.New("decode response: unexpected EOF"))
+
+	svc := New(nil, weatherAPI, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.April, 11, 12, 7, 27, 0, time.UTC)
+	}
+
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	data, err := svc.Weather().Compare(context.Background(), []string{"Paris", "Lyon|Nice"}, units.Metric, "en", services.OutputMarkdown)
+	require.NoError(t, err)
+
+	// The server fault is logged once, although both the summary and the
+	// JSON show it.
+	assert.Equal(t, 1, strings.Count(logs.String(), "unexpected EOF"))
+
+	assert.Equal(t, "## 🌍 Weather comparison\n\n"+
+		"| Location | Condition | Temperature | Feels Like | Humidity | Wind Speed | Pressure | Local Time |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| Paris, France | Sun \\| showers | 15°C | 14°C | 60% | 10 km/h | 1015 mb | 14:07 CEST |\n"+
+		"| Lyon\\|Nice | ⚠️ "+services.GenericErrorMessage+" |  |  |  |  |  |  |", data.Summary)
+
+	assert.NotContains(t, data.JSON, "unexpected EOF")
+}
+
+func TestCompareWorkers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var inFlight, most atomic.Int64
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				seen := most.Load()
+				if n <= seen || most.CompareAndSwap(seen, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			return &models.CurrentResponse{Location: models.Location{Name: city}}, nil
+		}).
+		Times(10)
+
+	svc := New(nil, weatherAPI, nil, nil)
+
+	cities := make([]string, 10)
+	for i := range cities {
+		cities[i] = fmt.Sprintf("city-%d", i)
+	}
+
+	data, err := svc.Weather().Compare(context.Background(), cities, units.Metric, "en", services.OutputJSON)
+	require.NoError(t, err)
+
+	var report comparisonReport
+
+	require.NoError(t, json.Unmarshal([]byte(data.JSON), &report))
+
+	for i, location := range report.Locations {
+		assert.Equal(t, cities[i], location.Weather.Location.Name)
+	}
+
+	assert.LessOrEqual(t, most.Load(), int64(compareWorkers))
+	assert.Greater(t, most.Load(), int64(1))
+}
+
+func TestCompareCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(gomock.Any(), "London").
+		Return(nil, context.Canceled)
+
+	svc := New(nil, weatherAPI, nil, nil)
+
+	_, err := svc.Weather().Compare(ctx, []string{"London"}, units.Metric, "en", services.OutputJSON)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -1,22 +1,40 @@
 package core
 
 import (
+	"bytes"
+	"encoding/json"
 	"html/template"
+	"strings"
+	"time"
 
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/recommend"
 )
 
 type CoreServices struct {
-	renderer   *template.Template
-	weatherAPI services.WeatherAPIProvider
+	renderer        *template.Template
+	weatherAPI      services.WeatherAPIProvider
+	quota           services.QuotaProvider
+	recommendations *recommend.Catalog
+	now             func() time.Time
 
 	weatherService *WeatherService
+	quotaService   *QuotaService
 }
 
-func New(renderer *template.Template, weatherAPI services.WeatherAPIProvider) *CoreServices {
+// New returns the core services. quota may be nil when no upstream call
+// budget is kept, and recommendations nil for the built-in catalog.
+func New(renderer *template.Template, weatherAPI services.WeatherAPIProvider, quota services.QuotaProvider, recommendations *recommend.Catalog) *CoreServices {
+	if recommendations == nil {
+		recommendations = recommend.Default()
+	}
+
 	return &CoreServices{
-		renderer:   renderer,
-		weatherAPI: weatherAPI,
+		renderer:        renderer,
+		weatherAPI:      weatherAPI,
+		quota:           quota,
+		recommendations: recommendations,
+		now:             time.Now,
 	}
 }
 
@@ -27,3 +45,26 @@ func (cs *CoreServices) Weather() services.WeatherService {
 
 	return cs.weatherService
 }
+
+func (cs *CoreServices) Quota() services.QuotaService {
+	if cs.quotaService == nil {
+		cs.quotaService = &QuotaService{CoreServices: cs}
+	}
+
+	return cs.quotaService
+}
+
+// marshalJSON encodes v without HTML escaping, since the output is read by
+// agents rather than embedded in a page.
+func marshalJSON(v any) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,368 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/schema"
+	"github.com/TuanKiri/weather-mcp-server/pkg/astro"
+	"github.com/TuanKiri/weather-mcp-server/pkg/recommend"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// currentView is the current weather as shown to people, in the HTML,
+// Markdown and text outputs.
+type currentView struct {
+	Lang                string
+	Messages            *i18n.Catalog
+	Location            string
+	Icon                string
+	Condition           string
+	Temperature         string
+	Humidity            string
+	WindSpeed           string
+	FeelsLike           string
+	Pressure            string
+	CityImage           string
+	FunFact             string
+	WeatherTrend        string
+	RecommendationsList []string
+	Consensus           string
+	// Observed is when the weather was observed with its age, empty if the
+	// provider did not say. Both it and LocalTime are in the location's
+	// time zone.
+	Observed  string
+	LocalTime string
+}
+
+func newCurrentView(messages *i18n.Catalog, system units.Set, data *models.CurrentResponse, recommendations *recommend.Catalog, weather recommend.Weather) *currentView {
+	condition := currentCondition(data, weather.Time)
+	obs := newObservation(data, weather.Time)
+
+	return &currentView{
+		Lang:                messages.Lang(),
+		Messages:            messages,
+		Location:            fmt.Sprintf("%s, %s", data.Location.Name, data.Location.Country),
+		Icon:                "https:" + condition.Icon,
+		Condition:           condition.Text,
+		Temperature:         system.FormatTemperature(data.Current.TempC),
+		Humidity:            reading(&data.Current, models.FieldHumidity, fmt.Sprintf("%d", data.Current.Humidity)),
+		WindSpeed:           reading(&data.Current, models.FieldWindKph, system.FormatWind(data.Current.WindKph)),
+		FeelsLike:           system.FormatTemperature(data.Current.FeelslikeC),
+		Pressure:            reading(&data.Current, models.FieldPressureMb, system.FormatPressure(data.Current.PressureMb)),
+		CityImage:           recommendations.Place(weather, messages.Lang()),
+		FunFact:             recommendations.Fact(weather, messages.Lang()),
+		WeatherTrend:        getWeatherTrend(messages, weather.TempC, weather.Code),
+		RecommendationsList: recommendations.Advice(weather, messages.Lang()),
+		Consensus:           consensusNote(messages, data.Current.Consensus, system),
+		Observed:            obs.text(messages),
+		LocalTime:           obs.local.Format(clockFormat),
+	}
+}
+
+// noReading stands in for a measurement the provider did not report.
+const noReading = "—"
+
+// reading returns the formatted value of a measurement, or noReading if the
+// provider did not report it.
+func reading(current *models.Current, field, formatted string) string {
+	if !current.Has(field) {
+		return noReading
+	}
+
+	return formatted
+}
+
+// clockFormat shows a time with its zone, such as 2025-04-11 13:00 BST.
+const clockFormat = "2006-01-02 15:04 MST"
+
+// observation is when the weather was observed and the time now, both in
+// the location's time zone.
+type observation struct {
+	// observed is zero if the provider did not say.
+	observed time.Time
+	local    time.Time
+}
+
+func newObservation(data *models.CurrentResponse, now time.Time) observation {
+	zone := timeZone(data.Location)
+
+	obs := observation{local: now.In(zone)}
+
+	if epoch := data.Current.LastUpdatedEpoch; epoch > 0 {
+		obs.observed = time.Unix(epoch, 0).In(zone)
+	}
+
+	return obs
+}
+
+// age returns how old the observation is. An observation stamped ahead of
+// the server's clock is taken as new.
+func (o observation) age() time.Duration {
+	return max(o.local.Sub(o.observed), 0)
+}
+
+// text returns the observation time with its age, such as
+// "2025-04-11 13:00 BST (7 min ago)", or "" if it is unknown.
+func (o observation) text(messages *i18n.Catalog) string {
+	if o.observed.IsZero() {
+		return ""
+	}
+
+	var age string
+
+	switch minutes := int(o.age() / time.Minute); {
+	case minutes < 1:
+		age = messages.Text("age.just_now")
+	case minutes < 60:
+		age = messages.Text("age.minutes", minutes)
+	default:
+		age = messages.Text("age.hours", minutes/60, minutes%60)
+	}
+
+	return fmt.Sprintf("%s (%s)", o.observed.Format(clockFormat), age)
+}
+
+// currentCondition returns the current condition. When the provider gave a
+// code without an icon, the text and icon are filled in from the code, by
+// day or by night depending on whether the sun is up at the location.
+func currentCondition(data *models.CurrentResponse, now time.Time) models.Condition {
+	condition := data.Current.Condition
+	if condition.Icon != "" || condition.Code == 0 {
+		return condition
+	}
+
+	filled := weatherapi.NewCondition(condition.Code, astro.IsDay(data.Location.Lat, data.Location.Lon, now))
+	if condition.Text != "" {
+		filled.Text = condition.Text
+	}
+
+	return filled
+}
+
+// recommendWeather describes the weather for picking recommendations. A
+// location id query lets the catalog match the city by id.
+func recommendWeather(city string, data *models.CurrentResponse, now time.Time) recommend.Weather {
+	weather := recommend.Weather{
+		Query:    city,
+		Name:     data.Location.Name,
+		Lat:      data.Location.Lat,
+		Time:     now,
+		Code:     data.Current.Condition.Code,
+		TempC:    data.Current.TempC,
+		Humidity: int(data.Current.Humidity),
+		WindKph:  data.Current.WindKph,
+	}
+
+	if id, ok := weatherapi.ParseLocationID(city); ok {
+		weather.ID = id
+	}
+
+	return weather
+}
+
+type currentDetail struct {
+	emoji string
+	label string
+	value string
+}
+
+// details returns the measurements in the order the HTML view lists them.
+func (v *currentView) details() []currentDetail {
+	details := []currentDetail{
+		{"🌡️", v.Messages.Text("label.temperature"), v.Temperature},
+		{"☁️", v.Messages.Text("label.condition"), v.Condition},
+		{"💧", v.Messages.Text("label.humidity"), v.HumidityPercent()},
+		{"💨", v.Messages.Text("label.wind_speed"), v.WindSpeed},
+		{"🌡️", v.Messages.Text("label.feels_like"), v.FeelsLike},
+		{"🧭", v.Messages.Text("label.pressure"), v.Pressure},
+	}
+
+	if v.Observed != "" {
+		details = append(details, currentDetail{"🕒", v.Messages.Text("label.observed"), v.Observed})
+	}
+
+	return append(details, currentDetail{"🗺️", v.Messages.Text("label.local_time"), v.LocalTime})
+}
+
+// HumidityPercent returns the humidity with its percent sign, if it was
+// reported.
+func (v *currentView) HumidityPercent() string {
+	if v.Humidity == noReading {
+		return v.Humidity
+	}
+
+	return v.Humidity + "%"
+}
+
+func (v *currentView) markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "## %s\n\n", v.Location)
+	fmt.Fprintf(&sb, "📍 %s\n\n", v.CityImage)
+
+	for _, detail := range v.details() {
+		fmt.Fprintf(&sb, "- %s **%s:** %s\n", detail.emoji, detail.label, detail.value)
+	}
+
+	fmt.Fprintf(&sb, "\n📊 %s\n\n", v.WeatherTrend)
+
+	if v.Consensus != "" {
+		fmt.Fprintf(&sb, "🛰️ %s\n\n", v.Consensus)
+	}
+
+	fmt.Fprintf(&sb, "💡 %s\n\n", v.FunFact)
+	fmt.Fprintf(&sb, "### 🎯 %s\n\n", v.Messages.Text("label.recommendations"))
+
+	for _, recommendation := range v.RecommendationsList {
+		fmt.Fprintf(&sb, "- %s\n", recommendation)
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (v *currentView) text() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s\n", v.Location)
+	fmt.Fprintf(&sb, "📍 %s\n\n", v.CityImage)
+
+	for _, detail := range v.details() {
+		fmt.Fprintf(&sb, "%s %s: %s\n", detail.emoji, detail.label, detail.value)
+	}
+
+	fmt.Fprintf(&sb, "\n📊 %s\n", v.WeatherTrend)
+
+	if v.Consensus != "" {
+		fmt.Fprintf(&sb, "🛰️ %s\n", v.Consensus)
+	}
+
+	fmt.Fprintf(&sb, "💡 %s\n\n", v.FunFact)
+	fmt.Fprintf(&sb, "🎯 %s:\n", v.Messages.Text("label.recommendations"))
+
+	for _, recommendation := range v.RecommendationsList {
+		fmt.Fprintf(&sb, "  %s\n", recommendation)
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// currentReport is version 1 of the structured current weather, described
+// by the schema at schema.CurrentWeatherURI. It has its own types rather
+// than reusing the provider models so that the schema only changes on
+// purpose.
+type currentReport struct {
+	Schema       string             `json:"schema"`
+	Lang         string             `json:"lang"`
+	Location     locationReport     `json:"location"`
+	Condition    conditionReport    `json:"condition"`
+	TemperatureC float64            `json:"temperature_c"`
+	FeelsLikeC   float64            `json:"feels_like_c"`
+	Humidity     int64              `json:"humidity"`
+	WindKph      float64            `json:"wind_kph"`
+	WindDir      string             `json:"wind_dir"`
+	GustKph      float64            `json:"gust_kph"`
+	PressureMb   float64            `json:"pressure_mb"`
+	VisibilityKm float64            `json:"visibility_km"`
+	UV           float64            `json:"uv"`
+	Display      displayReport      `json:"display"`
+	Consensus    *consensusReport   `json:"consensus,omitempty"`
+	Observation  *observationReport `json:"observation,omitempty"`
+	Missing      []string           `json:"missing,omitempty"`
+}
+
+type locationReport struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region"`
+	Country   string  `json:"country"`
+	Lat       float64 `json:"lat"`
+	Lon       float64 `json:"lon"`
+	TzID      string  `json:"tz_id"`
+	LocalTime string  `json:"local_time"`
+}
+
+type conditionReport struct {
+	Text string `json:"text"`
+	Code int64  `json:"code"`
+}
+
+type displayReport struct {
+	Temperature string `json:"temperature"`
+	FeelsLike   string `json:"feels_like"`
+	Wind        string `json:"wind"`
+	Pressure    string `json:"pressure"`
+}
+
+type observationReport struct {
+	Time       string `json:"time"`
+	AgeSeconds int64  `json:"age_seconds"`
+}
+
+type consensusReport struct {
+	Providers      int     `json:"providers"`
+	TempSpreadC    float64 `json:"temp_spread_c"`
+	ConditionVotes int     `json:"condition_votes"`
+}
+
+func newCurrentReport(messages *i18n.Catalog, system units.Set, data *models.CurrentResponse, now time.Time) currentReport {
+	current := data.Current
+	obs := newObservation(data, now)
+
+	report := currentReport{
+		Schema: schema.CurrentWeatherURI,
+		Lang:   messages.Lang(),
+		Location: locationReport{
+			Name:      data.Location.Name,
+			Region:    data.Location.Region,
+			Country:   data.Location.Country,
+			Lat:       data.Location.Lat,
+			Lon:       data.Location.Lon,
+			TzID:      obs.local.Location().String(),
+			LocalTime: obs.local.Format(time.RFC3339),
+		},
+		Condition: conditionReport{
+			Text: current.Condition.Text,
+			Code: current.Condition.Code,
+		},
+		TemperatureC: current.TempC,
+		FeelsLikeC:   current.FeelslikeC,
+		Humidity:     current.Humidity,
+		WindKph:      current.WindKph,
+		WindDir:      current.WindDir,
+		GustKph:      current.GustKph,
+		PressureMb:   current.PressureMb,
+		VisibilityKm: current.Visibility,
+		UV:           current.UV,
+		Display: displayReport{
+			Temperature: system.FormatTemperature(current.TempC),
+			FeelsLike:   system.FormatTemperature(current.FeelslikeC),
+			Wind:        reading(&current, models.FieldWindKph, system.FormatWind(current.WindKph)),
+			Pressure:    reading(&current, models.FieldPressureMb, system.FormatPressure(current.PressureMb)),
+		},
+	}
+
+	if len(current.Missing) > 0 {
+		report.Missing = append([]string{}, current.Missing...)
+	}
+
+	if consensus := current.Consensus; consensus != nil {
+		report.Consensus = &consensusReport{
+			Providers:      consensus.Providers,
+			TempSpreadC:    consensus.TempSpreadC,
+			ConditionVotes: consensus.ConditionVotes,
+		}
+	}
+
+	if !obs.observed.IsZero() {
+		report.Observation = &observationReport{
+			Time:       obs.observed.Format(time.RFC3339),
+			AgeSeconds: int64(obs.age() / time.Second),
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,327 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// rating is how suitable the sea is for an activity, from best to worst.
+type rating int
+
+const (
+	ratingGood rating = iota
+	ratingFair
+	ratingPoor
+	ratingDangerous
+)
+
+func (r rating) String() string {
+	switch r {
+	case ratingGood:
+		return "good"
+	case ratingFair:
+		return "fair"
+	case ratingPoor:
+		return "poor"
+	default:
+		return "dangerous"
+	}
+}
+
+type verdict struct {
+	Rating  string   `json:"rating"`
+	Reasons []string `json:"reasons"`
+}
+
+// assessment collects the factors against an activity. The rating is that
+// of the worst factor.
+type assessment struct {
+	rating  rating
+	reasons []string
+}
+
+func (a *assessment) add(r rating, reason string) {
+	if r == ratingGood {
+		return
+	}
+
+	a.rating = max(a.rating, r)
+	a.reasons = append(a.reasons, reason)
+}
+
+func (a *assessment) verdict() verdict {
+	return verdict{Rating: a.rating.String(), Reasons: append([]string{}, a.reasons...)}
+}
+
+type tideReport struct {
+	Time    string   `json:"time"`
+	Type    string   `json:"type"`
+	HeightM *float64 `json:"height_m"`
+}
+
+type marineDayReport struct {
+	Date            string       `json:"date"`
+	MaxWaveHeightM  float64      `json:"max_wave_height_m"`
+	SwellHeightM    float64      `json:"swell_height_m"`
+	SwellPeriodSecs float64      `json:"swell_period_secs"`
+	SwellDir        string       `json:"swell_dir"`
+	MaxWindKph      float64      `json:"max_wind_kph"`
+	MaxGustKph      float64      `json:"max_gust_kph"`
+	WaterTempC      float64      `json:"water_temp_c"`
+	Tides           []tideReport `json:"tides"`
+	Sailing         verdict      `json:"sailing"`
+	Swimming        verdict      `json:"swimming"`
+}
+
+type marineReport struct {
+	Location string            `json:"location"`
+	Days     []marineDayReport `json:"days"`
+}
+
+// Marine reports waves, swell, wind, water temperature and tides for each
+// day, with how suitable the daylight hours are for sailing and swimming.
+func (ws *WeatherService) Marine(ctx context.Context, city string, days int, system units.Set) (*services.StructuredResult, error) {
+	provider, ok := ws.weatherAPI.(services.MarineProvider)
+	if !ok {
+		return nil, fmt.Errorf("marine forecast: %w", errors.ErrUnsupported)
+	}
+
+	data, err := provider.Marine(ctx, city, days)
+	if err != nil {
+		return nil, ws.locationError(ctx, city, err)
+	}
+
+	report := marineReport{
+		Location: fmt.Sprintf("%s, %s", data.Location.Name, data.Location.Country),
+		Days:     []marineDayReport{},
+	}
+
+	for _, day := range data.Forecast.ForecastDay {
+		if len(day.Hour) == 0 {
+			continue
+		}
+
+		report.Days = append(report.Days, newMarineDayReport(day, system))
+	}
+
+	if len(report.Days) == 0 {
+		return nil, services.ErrNoMarineData
+	}
+
+	structured, err := marshalJSON(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.StructuredResult{
+		Summary: marineSummary(report, system),
+		JSON:    structured,
+	}, nil
+}
+
+// newMarineDayReport summarises the daylight hours of a day, or all of them
+// if none are in daylight. The swell is that of the hour with the highest
+// waves.
+func newMarineDayReport(day models.MarineForecastDay, system units.Set) marineDayReport {
+	hours := daylightHours(day.Hour)
+
+	report := marineDayReport{
+		Date:  day.Date,
+		Tides: []tideReport{},
+	}
+
+	var waterTempC float64
+
+	for i, hour := range hours {
+		if i == 0 || hour.SigHtMt > report.MaxWaveHeightM {
+			report.MaxWaveHeightM = hour.SigHtMt
+			report.SwellHeightM = hour.SwellHtMt
+			report.SwellPeriodSecs = hour.SwellPeriodSecs
+			report.SwellDir = hour.SwellDir16Point
+		}
+
+		report.MaxWindKph = max(report.MaxWindKph, hour.WindKph)
+		report.MaxGustKph = max(report.MaxGustKph, hour.GustKph)
+		waterTempC += hour.WaterTempC
+	}
+
+	report.WaterTempC = math.Round(waterTempC/float64(len(hours))*10) / 10
+
+	for _, tides := range day.Day.Tides {
+		for _, tide := range tides.Tide {
+			report.Tides = append(report.Tides, newTideReport(tide))
+		}
+	}
+
+	report.Sailing = sailingVerdict(report, system)
+	report.Swimming = swimmingVerdict(report, system)
+
+	return report
+}
+
+func daylightHours(hours []models.MarineHour) []models.MarineHour {
+	var daylight []models.MarineHour
+
+	for _, hour := range hours {
+		if hour.IsDay == 1 {
+			daylight = append(daylight, hour)
+		}
+	}
+
+	if len(daylight) == 0 {
+		return hours
+	}
+
+	return daylight
+}
+
+// newTideReport converts a tide. The height is left out if it is not a
+// number.
+func newTideReport(tide models.Tide) tideReport {
+	report := tideReport{
+		Time: tide.TideTime,
+		Type: strings.ToLower(tide.TideType),
+	}
+
+	if height, err := strconv.ParseFloat(tide.TideHeightMt, 64); err == nil {
+		report.HeightM = &height
+	}
+
+	return report
+}
+
+// sailingVerdict judges the wind by the Beaufort scale: forces 3 and 4 are
+// ideal, force 6 is too much for most small boats and a gale is dangerous.
+// Too little wind is poor as well.
+func sailingVerdict(day marineDayReport, system units.Set) verdict {
+	var a assessment
+
+	wind := system.FormatWind(day.MaxWindKph)
+
+	switch force := units.BeaufortForce(day.MaxWindKph); {
+	case force >= 8:
+		a.add(ratingDangerous, "gale-force wind of "+wind)
+	case force >= 6:
+		a.add(ratingPoor, "strong wind of "+wind)
+	case force == 5:
+		a.add(ratingFair, "fresh wind of "+wind+", for experienced sailors")
+	case force <= 1:
+		a.add(ratingPoor, "too little wind to sail, "+wind)
+	case force == 2:
+		a.add(ratingFair, "light wind of "+wind)
+	}
+
+	gusts := system.FormatWind(day.MaxGustKph)
+
+	switch {
+	case day.MaxGustKph >= 75:
+		a.add(ratingDangerous, "gusts of "+gusts)
+	case day.MaxGustKph >= 50:
+		a.add(ratingPoor, "gusts of "+gusts)
+	case day.MaxGustKph >= 40:
+		a.add(ratingFair, "gusts of "+gusts)
+	}
+
+	waves := system.FormatHeight(day.MaxWaveHeightM)
+
+	switch {
+	case day.MaxWaveHeightM >= 4:
+		a.add(ratingDangerous, "very rough sea with waves of "+waves)
+	case day.MaxWaveHeightM >= 2.5:
+		a.add(ratingPoor, "rough sea with waves of "+waves)
+	case day.MaxWaveHeightM >= 1.5:
+		a.add(ratingFair, "moderate sea with waves of "+waves)
+	}
+
+	return a.verdict()
+}
+
+// swimmingVerdict judges the waves, the wind and the water temperature for
+// swimming in the open sea.
+func swimmingVerdict(day marineDayReport, system units.Set) verdict {
+	var a assessment
+
+	waves := system.FormatHeight(day.MaxWaveHeightM)
+
+	switch {
+	case day.MaxWaveHeightM >= 2:
+		a.add(ratingDangerous, "waves of "+waves)
+	case day.MaxWaveHeightM >= 1:
+		a.add(ratingPoor, "waves of "+waves)
+	case day.MaxWaveHeightM >= 0.5:
+		a.add(ratingFair, "waves of "+waves)
+	}
+
+	wind := system.FormatWind(day.MaxWindKph)
+
+	switch {
+	case day.MaxWindKph >= 50 || day.MaxGustKph >= 62:
+		a.add(ratingDangerous, "wind of "+wind+", gusts of "+system.FormatWind(day.MaxGustKph))
+	case day.MaxWindKph >= 39:
+		a.add(ratingPoor, "wind of "+wind)
+	case day.MaxWindKph >= 29:
+		a.add(ratingFair, "wind of "+wind)
+	}
+
+	water := system.FormatTemperature(day.WaterTempC)
+
+	switch {
+	case day.WaterTempC < 12:
+		a.add(ratingPoor, "cold water of "+water)
+	case day.WaterTempC < 18:
+		a.add(ratingFair, "cool water of "+water)
+	}
+
+	return a.verdict()
+}
+
+func marineSummary(report marineReport, system units.Set) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🌊 Marine forecast for %s:\n", report.Location)
+
+	for _, day := range report.Days {
+		fmt.Fprintf(&sb, "\n%s\n", day.Date)
+		fmt.Fprintf(&sb, "   • Waves up to %s, swell %s from %s every %.0f s\n",
+			system.FormatHeight(day.MaxWaveHeightM), system.FormatHeight(day.SwellHeightM),
+			day.SwellDir, day.SwellPeriodSecs)
+		fmt.Fprintf(&sb, "   • Wind up to %s, gusts %s\n", system.FormatWind(day.MaxWindKph), system.FormatWind(day.MaxGustKph))
+		fmt.Fprintf(&sb, "   • Water %s\n", system.FormatTemperature(day.WaterTempC))
+
+		if len(day.Tides) > 0 {
+			tides := make([]string, 0, len(day.Tides))
+
+			for _, tide := range day.Tides {
+				text := tide.Type + " " + strings.TrimPrefix(tide.Time, day.Date+" ")
+				if tide.HeightM != nil {
+					text += " (" + system.FormatHeight(*tide.HeightM) + ")"
+				}
+
+				tides = append(tides, text)
+			}
+
+			fmt.Fprintf(&sb, "   • Tides: %s\n", strings.Join(tides, ", "))
+		}
+
+		fmt.Fprintf(&sb, "   • ⛵ Sailing: %s\n", verdictText(day.Sailing))
+		fmt.Fprintf(&sb, "   • 🏊 Swimming: %s\n", verdictText(day.Swimming))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func verdictText(v verdict) string {
+	if len(v.Reasons) == 0 {
+		return v.Rating
+	}
+
+	return fmt.Sprintf("%s (%s)", v.Rating, strings.Join(v.Reasons, "; "))
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type marineProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockMarineProvider
+}
+
+func TestMarine(t *testing.T) {
+	brighton := models.Location{Name: "Brighton", Country: "United Kingdom"}
+
+	calm := models.MarineForecastDay{
+		Date: "2025-06-21",
+		Day: models.MarineDay{Tides: []models.Tides{{Tide: []models.Tide{
+			{TideTime: "2025-06-21 04:12", TideHeightMt: "5.82", TideType: "HIGH"},
+			{TideTime: "2025-06-21 10:31", TideHeightMt: "1.14", TideType: "LOW"},
+		}}}},
+		Hour: []models.MarineHour{
+			{Time: "2025-06-21 03:00", WindKph: 45, GustKph: 60, SigHtMt: 2.2, WaterTempC: 15},
+			{Time: "2025-06-21 09:00", IsDay: 1, WindKph: 14.4, GustKph: 19.8, SigHtMt: 0.3,
+				SwellHtMt: 0.2, SwellDir16Point: "SW", SwellPeriodSecs: 7.2, WaterTempC: 18.4},
+			{Time: "2025-06-21 10:00", IsDay: 1, WindKph: 20.5, GustKph: 27.4, SigHtMt: 0.4,
+				SwellHtMt: 0.3, SwellDir16Point: "WSW", SwellPeriodSecs: 7.6, WaterTempC: 18.8},
+		},
+	}
+
+	gale := models.MarineForecastDay{
+		Date: "2025-06-22",
+		Hour: []models.MarineHour{
+			{Time: "2025-06-22 12:00", IsDay: 1, WindKph: 68, GustKph: 95, SigHtMt: 4.6,
+				SwellHtMt: 3.1, SwellDir16Point: "W", SwellPeriodSecs: 11, WaterTempC: 16},
+		},
+	}
+
+	testCases := map[string]struct {
+		units           units.Set
+		errString       string
+		wait            *services.StructuredResult
+		setupWeatherAPI func(marine *mock.MockMarineProvider)
+	}{
+		"city_not_found": {
+			errString: "weather API not available. Code: 400",
+			setupWeatherAPI: func(marine *mock.MockMarineProvider) {
+				marine.EXPECT().
+					Marine(context.Background(), "Brighton", 2).
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"no_marine_data": {
+			errString: "marine data is not available for this location",
+			setupWeatherAPI: func(marine *mock.MockMarineProvider) {
+				marine.EXPECT().
+					Marine(context.Background(), "Brighton", 2).
+					Return(&models.MarineResponse{Location: brighton}, nil)
+			},
+		},
+		"calm_then_gale": {
+			units: units.Metric,
+			wait: &services.StructuredResult{
+				Summary: "🌊 Marine forecast for Brighton, United Kingdom:\n\n" +
+					"2025-06-21\n" +
+					"   • Waves up to 0.4 m, swell 0.3 m from WSW every 8 s\n" +
+					"   • Wind up to 20 km/h, gusts 27 km/h\n" +
+					"   • Water 19°C\n" +
+					"   • Tides: high 04:12 (5.8 m), low 10:31 (1.1 m)\n" +
+					"   • ⛵ Sailing: good\n" +
+					"   • 🏊 Swimming: good\n\n" +
+					"2025-06-22\n" +
+					"   • Waves up to 4.6 m, swell 3.1 m from W every 11 s\n" +
+					"   • Wind up to 68 km/h, gusts 95 km/h\n" +
+					"   • Water 16°C\n" +
+					"   • ⛵ Sailing: dangerous (gale-force wind of 68 km/h; gusts of 95 km/h; very rough sea with waves of 4.6 m)\n" +
+					"   • 🏊 Swimming: dangerous (waves of 4.6 m; wind of 68 km/h, gusts of 95 km/h; cool water of 16°C)",
+				JSON: `{"location":"Brighton, United Kingdom","days":[` +
+					`{"date":"2025-06-21","max_wave_height_m":0.4,"swell_height_m":0.3,"swell_period_secs":7.6,"swell_dir":"WSW",` +
+					`"max_wind_kph":20.5,"max_gust_kph":27.4,"water_temp_c":18.6,"tides":[` +
+					`{"time":"2025-06-21 04:12","type":"high","height_m":5.82},{"time":"2025-06-21 10:31","type":"low","height_m":1.14}],` +
+					`"sailing":{"rating":"good","reasons":[]},"swimming":{"rating":"good","reasons":[]}},` +
+					`{"date":"2025-06-22","max_wave_height_m":4.6,"swell_height_m":3.1,"swell_period_secs":11,"swell_dir":"W",` +
+					`"max_wind_kph":68,"max_gust_kph":95,"water_temp_c":16,"tides":[],` +
+					`"sailing":{"rating":"dangerous","reasons":["gale-force wind of 68 km/h","gusts of 95 km/h","very rough sea with waves of 4.6 m"]},` +
+					`"swimming":{"rating":"dangerous","reasons":["waves of 4.6 m","wind of 68 km/h, gusts of 95 km/h","cool water of 16°C"]}}]}`,
+			},
+			setupWeatherAPI: func(marine *mock.MockMarineProvider) {
+				marine.EXPECT().
+					Marine(context.Background(), "Brighton", 2).
+					Return(&models.MarineResponse{
+						Location: brighton,
+						Forecast: models.MarineForecast{ForecastDay: []models.MarineForecastDay{calm, gale}},
+					}, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	marine := mock.NewMockMarineProvider(ctrl)
+
+	svc := New(nil, marineProvider{mock.NewMockWeatherAPIProvider(ctrl), marine}, nil, nil)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherAPI != nil {
+				tc.setupWeatherAPI(marine)
+			}
+
+			data, err := svc.Weather().Marine(context.Background(), "Brighton", 2, tc.units)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
+
+func TestMarineVerdicts(t *testing.T) {
+	testCases := map[string]struct {
+		day      marineDayReport
+		sailing  verdict
+		swimming verdict
+	}{
+		"no_wind": {
+			day:      marineDayReport{MaxWindKph: 3, MaxGustKph: 5, MaxWaveHeightM: 0.1, WaterTempC: 22},
+			sailing:  verdict{Rating: "poor", Reasons: []string{"too little wind to sail, 3 km/h"}},
+			swimming: verdict{Rating: "good", Reasons: []string{}},
+		},
+		"fresh_breeze": {
+			day: marineDayReport{MaxWindKph: 32, MaxGustKph: 44, MaxWaveHeightM: 1.2, WaterTempC: 10},
+			sailing: verdict{Rating: "fair", Reasons: []string{
+				"fresh wind of 32 km/h, for experienced sailors", "gusts of 44 km/h",
+			}},
+			swimming: verdict{Rating: "poor", Reasons: []string{
+				"waves of 1.2 m", "wind of 32 km/h", "cold water of 10°C",
+			}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.sailing, sailingVerdict(tc.day, units.Metric))
+			assert.Equal(t, tc.swimming, swimmingVerdict(tc.day, units.Metric))
+		})
+	}
+}
+
+func TestMarineUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := New(nil, mock.NewMockWeatherAPIProvider(ctrl), nil, nil)
+
+	_, err := svc.Weather().Marine(context.Background(), "Brighton", 1, units.Metric)
+
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
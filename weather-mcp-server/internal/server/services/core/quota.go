@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+type QuotaService struct {
+	*CoreServices
+}
+
+type quotaReport struct {
+	Configured bool `json:"configured"`
+	*weatherapi.QuotaStatus
+}
+
+func (qs *QuotaService) Status(_ context.Context) (*services.StructuredResult, error) {
+	report := quotaReport{
+		Configured: qs.quota != nil,
+	}
+
+	if report.Configured {
+		status := qs.quota.Status()
+		report.QuotaStatus = &status
+	}
+
+	structured, err := marshalJSON(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.StructuredResult{
+		Summary: quotaSummary(report),
+		JSON:    structured,
+	}, nil
+}
+
+func quotaSummary(report quotaReport) string {
+	if !report.Configured {
+		return "ℹ️ No call budget is kept for the current weather provider."
+	}
+
+	status := report.QuotaStatus
+
+	var sb strings.Builder
+
+	sb.WriteString("📊 Weather API call budget:\n")
+	fmt.Fprintf(&sb, "   • Today: %s\n", budgetLine(status.DailyUsed, status.DailyLimit, status.DailyRemaining))
+	fmt.Fprintf(&sb, "   • This month: %s\n", budgetLine(status.MonthlyUsed, status.MonthlyLimit, status.MonthlyRemaining))
+
+	switch {
+	case status.Exhausted:
+		sb.WriteString("   • Mode: ⛔ the provider rejected the key for exceeding its monthly quota, serving cached data only")
+	case status.Degraded:
+		sb.WriteString("   • Mode: ⚠️ budget nearly used up, serving cached data only")
+	default:
+		sb.WriteString("   • Mode: ✅ normal")
+	}
+
+	if status.PersistError != "" {
+		fmt.Fprintf(&sb, "\n   • Warning: the counters could not be saved: %s", status.PersistError)
+	}
+
+	return sb.String()
+}
+
+func budgetLine(used, limit int64, remaining *int64) string {
+	if remaining == nil {
+		return fmt.Sprintf("%d calls used, no limit", used)
+	}
+
+	return fmt.Sprintf("%d of %d calls used, %d left", used, limit, *remaining)
+}
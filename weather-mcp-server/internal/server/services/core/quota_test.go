@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+func TestQuotaStatus(t *testing.T) {
+	remaining := int64(5)
+
+	testCases := map[string]struct {
+		status *weatherapi.QuotaStatus
+		wait   *services.StructuredResult
+	}{
+		"no_budget": {
+			wait: &services.StructuredResult{
+				Summary: "ℹ️ No call budget is kept for the current weather provider.",
+				JSON:    `{"configured":false}`,
+			},
+		},
+		"normal": {
+			status: &weatherapi.QuotaStatus{
+				DailyUsed:   12,
+				MonthlyUsed: 340,
+			},
+			wait: &services.StructuredResult{
+				Summary: "📊 Weather API call budget:\n" +
+					"   • Today: 12 calls used, no limit\n" +
+					"   • This month: 340 calls used, no limit\n" +
+					"   • Mode: ✅ normal",
+				JSON: `{"configured":true,"daily_limit":0,"daily_used":12,"daily_remaining":null,` +
+					`"monthly_limit":0,"monthly_used":340,"monthly_remaining":null,"exhausted":false,"degraded":false}`,
+			},
+		},
+		"degraded": {
+			status: &weatherapi.QuotaStatus{
+				DailyLimit:     100,
+				DailyUsed:      95,
+				DailyRemaining: &remaining,
+				MonthlyUsed:    340,
+				Degraded:       true,
+			},
+			wait: &services.StructuredResult{
+				Summary: "📊 Weather API call budget:\n" +
+					"   • Today: 95 of 100 calls used, 5 left\n" +
+					"   • This month: 340 calls used, no limit\n" +
+					"   • Mode: ⚠️ budget nearly used up, serving cached data only",
+				JSON: `{"configured":true,"daily_limit":100,"daily_used":95,"daily_remaining":5,` +
+					`"monthly_limit":0,"monthly_used":340,"monthly_remaining":null,"exhausted":false,"degraded":true}`,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var quota services.QuotaProvider
+
+			if tc.status != nil {
+				provider := mock.NewMockQuotaProvider(ctrl)
+				provider.EXPECT().Status().Return(*tc.status)
+				quota = provider
+			}
+
+			data, err := New(nil, nil, quota, nil).Quota().Status(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wait, data)
+		})
+	}
+}
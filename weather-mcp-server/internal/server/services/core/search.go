@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// maxSuggestions limits the places offered when a location is not found.
+const maxSuggestions = 3
+
+type placeReport struct {
+	ID      int64   `json:"id"`
+	Query   string  `json:"query"`
+	Name    string  `json:"name"`
+	Region  string  `json:"region"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+type searchReport struct {
+	Query     string        `json:"query"`
+	Count     int           `json:"count"`
+	Locations []placeReport `json:"locations"`
+}
+
+func (ws *WeatherService) SearchLocations(ctx context.Context, query string) (*services.StructuredResult, error) {
+	places, err := ws.search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	report := searchReport{
+		Query:     query,
+		Count:     len(places),
+		Locations: make([]placeReport, 0, len(places)),
+	}
+
+	for _, place := range places {
+		report.Locations = append(report.Locations, placeReport{
+			ID:      place.ID,
+			Query:   weatherapi.LocationQuery(place.ID),
+			Name:    place.Name,
+			Region:  place.Region,
+			Country: place.Country,
+			Lat:     place.Lat,
+			Lon:     place.Lon,
+		})
+	}
+
+	structured, err := marshalJSON(report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.StructuredResult{
+		Summary: searchSummary(report),
+		JSON:    structured,
+	}, nil
+}
+
+func (ws *WeatherService) search(ctx context.Context, query string) (models.SearchResponse, error) {
+	searcher, ok := ws.weatherAPI.(services.LocationSearcher)
+	if !ok {
+		return nil, fmt.Errorf("location search: %w", errors.ErrUnsupported)
+	}
+
+	return searcher.Search(ctx, query)
+}
+
+// locationError adds the places the city may have meant to an error for a
+// location that was not found. Any other error is returned unchanged, as
+// is the original error if the search fails or finds nothing.
+func (ws *WeatherService) locationError(ctx context.Context, city string, err error) error {
+	if !errors.Is(err, weatherapi.ErrLocationNotFound) || weatherapi.IsPrefixedQuery(city) {
+		return err
+	}
+
+	if _, _, ok := weatherapi.ParseCoordinates(city); ok {
+		return err
+	}
+
+	places, searchErr := ws.search(ctx, city)
+	if searchErr != nil || len(places) == 0 {
+		return err
+	}
+
+	locationErr := &weatherapi.LocationError{Query: city}
+
+	for _, place := range places[:min(len(places), maxSuggestions)] {
+		locationErr.Suggestions = append(locationErr.Suggestions, placeName(place.Name, place.Region, place.Country))
+	}
+
+	return locationErr
+}
+
+func searchSummary(report searchReport) string {
+	if report.Count == 0 {
+		return fmt.Sprintf("🔎 No locations match %q.", report.Query)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "🔎 %d location(s) match %q:\n", report.Count, report.Query)
+
+	for i, place := range report.Locations {
+		fmt.Fprintf(&sb, "\n%d. %s (%.2f, %.2f), %s",
+			i+1, placeName(place.Name, place.Region, place.Country), place.Lat, place.Lon, place.Query)
+	}
+
+	fmt.Fprintf(&sb, "\n\nUse a query such as %s as the city to get the weather for that exact place.",
+		report.Locations[0].Query)
+
+	return sb.String()
+}
+
+// placeName joins the non-empty parts of a place name.
+func placeName(parts ...string) string {
+	var names []string
+
+	for _, part := range parts {
+		if part != "" {
+			names = append(names, part)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type searchProvider struct {
+	*mock.MockWeatherAPIProvider
+	*mock.MockLocationSearcher
+}
+
+var springfields = models.SearchResponse{
+	{ID: 2618724, Name: "Springfield", Region: "Illinois", Country: "United States of America", Lat: 39.8, Lon: -89.64},
+	{ID: 2619102, Name: "Springfield", Region: "Missouri", Country: "United States of America", Lat: 37.22, Lon: -93.3},
+}
+
+func TestSearchLocations(t *testing.T) {
+	testCases := map[string]struct {
+		query       string
+		errString   string
+		wait        *services.StructuredResult
+		setupSearch func(searcher *mock.MockLocationSearcher)
+	}{
+		"search_failed": {
+			query:     "Springfield",
+			errString: "weather API not available. Code: 500",
+			setupSearch: func(searcher *mock.MockLocationSearcher) {
+				searcher.EXPECT().
+					Search(context.Background(), "Springfield").
+					Return(nil, errors.New("weather API not available. Code: 500"))
+			},
+		},
+		"no_locations": {
+			query: "Atlantis",
+			wait: &services.StructuredResult{
+				Summary: `🔎 No locations match "Atlantis".`,
+				JSON:    `{"query":"Atlantis","count":0,"locations":[]}`,
+			},
+			setupSearch: func(searcher *mock.MockLocationSearcher) {
+				searcher.EXPECT().
+					Search(context.Background(), "Atlantis").
+					Return(models.SearchResponse{}, nil)
+			},
+		},
+		"several_locations": {
+			query: "Springfield",
+			wait: &services.StructuredResult{
+				Summary: "🔎 2 location(s) match \"Springfield\":\n\n" +
+					"1. Springfield, Illinois, United States of America (39.80, -89.64), id:2618724\n" +
+					"2. Springfield, Missouri, United States of America (37.22, -93.30), id:2619102\n\n" +
+					"Use a query such as id:2618724 as the city to get the weather for that exact place.",
+				JSON: `{"query":"Springfield","count":2,"locations":[` +
+					`{"id":2618724,"query":"id:2618724","name":"Springfield","region":"Illinois",` +
+					`"country":"United States of America","lat":39.8,"lon":-89.64},` +
+					`{"id":2619102,"query":"id:2619102","name":"Springfield","region":"Missouri",` +
+					`"country":"United States of America","lat":37.22,"lon":-93.3}]}`,
+			},
+			setupSearch: func(searcher *mock.MockLocationSearcher) {
+				searcher.EXPECT().
+					Search(context.Background(), "Springfield").
+					Return(springfields, nil)
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	searcher := mock.NewMockLocationSearcher(ctrl)
+
+	svc := New(nil, searchProvider{mock.NewMockWeatherAPIProvider(ctrl), searcher}, nil, nil)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupSearch != nil {
+				tc.setupSearch(searcher)
+			}
+
+			result, err := svc.Weather().SearchLocations(context.Background(), tc.query)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestSearchUnsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := New(nil, mock.NewMockWeatherAPIProvider(ctrl), nil, nil)
+
+	_, err := svc.Weather().SearchLocations(context.Background(), "Springfield")
+
+	assert.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestLocationSuggestions(t *testing.T) {
+	notFound := &weatherapi.StatusError{API: "weather", StatusCode: 400, Code: 1006}
+
+	testCases := map[string]struct {
+		city        string
+		wait        error
+		setupSearch func(searcher *mock.MockLocationSearcher)
+	}{
+		"suggestions": {
+			city: "Springfeld",
+			wait: &weatherapi.LocationError{
+				Query: "Springfeld",
+				Suggestions: []string{
+					"Springfield, Illinois, United States of America",
+					"Springfield, Missouri, United States of America",
+				},
+			},
+			setupSearch: func(searcher *mock.MockLocationSearcher) {
+				searcher.EXPECT().
+					Search(context.Background(), "Springfeld").
+					Return(springfields, nil)
+			},
+		},
+		"nothing_similar": {
+			city: "Atlantis",
+			wait: notFound,
+			setupSearch: func(searcher *mock.MockLocationSearcher) {
+				searcher.EXPECT().
+					Search(context.Background(), "Atlantis").
+					Return(models.SearchResponse{}, nil)
+			},
+		},
+		"location_id_is_not_searched": {
+			city: "id:1",
+			wait: notFound,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	searcher := mock.NewMockLocationSearcher(ctrl)
+
+	svc := New(nil, searchProvider{weatherAPI, searcher}, nil, nil)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupSearch != nil {
+				tc.setupSearch(searcher)
+			}
+
+			weatherAPI.EXPECT().
+				Alerts(context.Background(), tc.city).
+				Return(nil, notFound)
+
+			_, err := svc.Weather().Alerts(context.Background(), tc.city)
+
+			assert.Equal(t, tc.wait, err)
+		})
+	}
+}
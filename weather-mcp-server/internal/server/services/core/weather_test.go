@@ -2,27 +2,35 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"html/template"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services/mock"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
 	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
 )
 
 func TestCurrentWeather(t *testing.T) {
 	testCases := map[string]struct {
 		city            string
+		units           units.Set
+		lang            string
 		errString       string
 		wait            string
 		setupWeatherAPI func(weatherAPI *mock.MockWeatherAPIProvider)
 	}{
 		"city_not_found": {
 			city:      "Tokyo",
+			lang:      "en",
 			errString: "weather API not available. Code: 400",
 			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
 				weatherAPI.EXPECT().
@@ -31,8 +39,10 @@ func TestCurrentWeather(t *testing.T) {
 			},
 		},
 		"successful_result": {
-			city: "London",
-			wait: "London, United Kingdom Sunny 18 45 4 " +
+			city:  "London",
+			units: units.Metric,
+			lang:  "en",
+			wait: "London, United Kingdom Sunny 18°C 45 4 km/h 1022 mb " +
 				"https://cdn.weatherapi.com/weather/64x64/day/113.png",
 			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
 				weatherAPI.EXPECT().
@@ -43,12 +53,108 @@ func TestCurrentWeather(t *testing.T) {
 							Country: "United Kingdom",
 						},
 						Current: models.Current{
-							TempC:    18.4,
-							WindKph:  4.2,
-							Humidity: 45,
+							TempC:      18.4,
+							WindKph:    4.2,
+							Humidity:   45,
+							PressureMb: 1022.3,
 							Condition: models.Condition{
 								Text: "Sunny",
 								Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+								Code: 1000,
+							},
+						},
+					}, nil)
+			},
+		},
+		"icon_by_night": {
+			city:  "London",
+			units: units.Metric,
+			lang:  "en",
+			wait: "London, United Kingdom Clear 14°C 70 6 km/h 1015 mb " +
+				"https://cdn.weatherapi.com/weather/64x64/night/113.png",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Current(context.Background(), "London").
+					Return(&models.CurrentResponse{
+						Location: models.Location{
+							Name:    "London",
+							Country: "United Kingdom",
+							Lat:     51.52,
+							Lon:     -0.11,
+						},
+						Current: models.Current{
+							TempC:      14,
+							WindKph:    6,
+							Humidity:   70,
+							PressureMb: 1015,
+							Condition:  models.Condition{Code: 1000},
+						},
+					}, nil)
+			},
+		},
+		"consensus_result": {
+			city:  "Paris",
+			units: units.Imperial,
+			lang:  "en",
+			wait: "Paris, France Cloudy 54°F 60 6 mph 30.06 inHg " +
+				"https://cdn.weatherapi.com/weather/64x64/day/119.png " +
+				"Combined from 3 sources: temperatures within 2.7°F, 2 of 3 agree on the condition",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Current(context.Background(), "Paris").
+					Return(&models.CurrentResponse{
+						Location: models.Location{
+							Name:    "Paris",
+							Country: "France",
+						},
+						Current: models.Current{
+							TempC:      12,
+							WindKph:    10,
+							Humidity:   60,
+							PressureMb: 1018,
+							Condition: models.Condition{
+								Text: "Cloudy",
+								Icon: "//cdn.weatherapi.com/weather/64x64/day/119.png",
+								Code: 1006,
+							},
+							Consensus: &models.Consensus{
+								Providers:      3,
+								TempSpreadC:    1.5,
+								ConditionVotes: 2,
+							},
+						},
+					}, nil)
+			},
+		},
+		"localized_result": {
+			city:  "Madrid",
+			units: units.Metric,
+			lang:  "es",
+			wait: "Madrid, Spain Nublado 12°C 60 10 km/h 1018 mb " +
+				"https://cdn.weatherapi.com/weather/64x64/day/119.png " +
+				"Combinado de 2 fuentes: temperaturas dentro de 0.8°C, 2 de 2 coinciden en el estado",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Current(context.Background(), "Madrid", gomock.Any()).
+					Return(&models.CurrentResponse{
+						Location: models.Location{
+							Name:    "Madrid",
+							Country: "Spain",
+						},
+						Current: models.Current{
+							TempC:      12,
+							WindKph:    10,
+							Humidity:   60,
+							PressureMb: 1018,
+							Condition: models.Condition{
+								Text: "Nublado",
+								Icon: "//cdn.weatherapi.com/weather/64x64/day/119.png",
+								Code: 1006,
+							},
+							Consensus: &models.Consensus{
+								Providers:      2,
+								TempSpreadC:    0.8,
+								ConditionVotes: 2,
 							},
 						},
 					}, nil)
@@ -58,7 +164,316 @@ func TestCurrentWeather(t *testing.T) {
 
 	renderer, err := template.New("weather.html").Parse(
 		"{{ .Location }} {{ .Condition }} {{ .Temperature }} " +
-			"{{ .Humidity }} {{ .WindSpeed }} {{ .Icon }}")
+			"{{ .Humidity }} {{ .WindSpeed }} {{ .Pressure }} {{ .Icon }}{{ with .Consensus }} {{ . }}{{ end }}")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+
+	svc := New(renderer, weatherAPI, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.June, 21, 23, 30, 0, 0, time.UTC)
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.setupWeatherAPI != nil {
+				tc.setupWeatherAPI(weatherAPI)
+			}
+
+			data, err := svc.Weather().Current(context.Background(), tc.city, tc.units, tc.lang, services.OutputHTML)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.Equal(t, tc.wait, data.Summary)
+		})
+	}
+}
+
+func TestCurrentWeatherOutput(t *testing.T) {
+	testCases := map[string]struct {
+		output services.Output
+		wait   string
+	}{
+		"markdown": {
+			output: services.OutputMarkdown,
+			wait: "## London, United Kingdom\n\n" +
+				"📍 🌉 Walk across Tower Bridge\n\n" +
+				"- 🌡️ **Temperature:** 18°C\n" +
+				"- ☁️ **Condition:** Sunny\n" +
+				"- 💧 **Humidity:** 45%\n" +
+				"- 💨 **Wind Speed:** 4 km/h\n" +
+				"- 🌡️ **Feels Like:** 17°C\n" +
+				"- 🧭 **Pressure:** 1022 mb\n" +
+				"- 🕒 **Observed:** 2025-04-11 13:00 BST (7 min ago)\n" +
+				"- 🗺️ **Local Time:** 2025-04-11 13:07 BST\n\n" +
+				"📊 🌤️ Moderate conditions - suitable for most activities\n\n" +
+				"💡 Sunny London! Great time to visit Hyde Park or take a Thames River cruise! ☀️\n\n" +
+				"### 🎯 Personalized Recommendations\n\n" +
+				"- 🧥 Light jacket recommended\n" +
+				"- ☕ Perfect for café visits and indoor activities\n" +
+				"- 🧴 Don't forget sunscreen!\n" +
+				"- 📸 Excellent conditions for photography\n" +
+				"- 🏛️ Explore the British Museum\n" +
+				"- ☕ Enjoy traditional afternoon tea",
+		},
+		"text": {
+			output: services.OutputText,
+			wait: "London, United Kingdom\n" +
+				"📍 🌉 Walk across Tower Bridge\n\n" +
+				"🌡️ Temperature: 18°C\n" +
+				"☁️ Condition: Sunny\n" +
+				"💧 Humidity: 45%\n" +
+				"💨 Wind Speed: 4 km/h\n" +
+				"🌡️ Feels Like: 17°C\n" +
+				"🧭 Pressure: 1022 mb\n" +
+				"🕒 Observed: 2025-04-11 13:00 BST (7 min ago)\n" +
+				"🗺️ Local Time: 2025-04-11 13:07 BST\n\n" +
+				"📊 🌤️ Moderate conditions - suitable for most activities\n" +
+				"💡 Sunny London! Great time to visit Hyde Park or take a Thames River cruise! ☀️\n\n" +
+				"🎯 Personalized Recommendations:\n" +
+				"  🧥 Light jacket recommended\n" +
+				"  ☕ Perfect for café visits and indoor activities\n" +
+				"  🧴 Don't forget sunscreen!\n" +
+				"  📸 Excellent conditions for photography\n" +
+				"  🏛️ Explore the British Museum\n" +
+				"  ☕ Enjoy traditional afternoon tea",
+		},
+		"json": {
+			output: services.OutputJSON,
+			wait: `{"schema":"weather://schema/current_weather/v1","lang":"en",` +
+				`"location":{"name":"London","region":"City of London, Greater London","country":"United Kingdom","lat":51.52,"lon":-0.11,` +
+				`"tz_id":"Europe/London","local_time":"2025-04-11T13:07:27+01:00"},` +
+				`"condition":{"text":"Sunny","code":1000},"temperature_c":18.4,"feels_like_c":17,"humidity":45,` +
+				`"wind_kph":4.2,"wind_dir":"WSW","gust_kph":0,"pressure_mb":1022,"visibility_km":10,"uv":4,` +
+				`"display":{"temperature":"18°C","feels_like":"17°C","wind":"4 km/h","pressure":"1022 mb"},` +
+				`"observation":{"time":"2025-04-11T13:00:00+01:00","age_seconds":447}}`,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(context.Background(), "London").
+		Return(&models.CurrentResponse{
+			Location: models.Location{
+				Name:    "London",
+				Region:  "City of London, Greater London",
+				Country: "United Kingdom",
+				Lat:     51.52,
+				Lon:     -0.11,
+				TzID:    "Europe/London",
+			},
+			Current: models.Current{
+				LastUpdatedEpoch: 1744372800,
+				TempC:            18.4,
+				FeelslikeC:       17,
+				WindKph:          4.2,
+				WindDir:          "WSW",
+				Humidity:         45,
+				PressureMb:       1022,
+				Visibility:       10,
+				UV:               4,
+				Condition: models.Condition{
+					Text: "Sunny",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+					Code: 1000,
+				},
+			},
+		}, nil).
+		Times(len(testCases))
+
+	svc := New(nil, weatherAPI, nil, nil)
+	svc.now = func() time.Time {
+		return time.Date(2025, time.April, 11, 12, 7, 27, 0, time.UTC)
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, err := svc.Weather().Current(context.Background(), "London", units.Metric, "en", tc.output)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wait, data.Summary)
+			assert.True(t, json.Valid([]byte(data.JSON)))
+		})
+	}
+}
+
+func TestCurrentWeatherMissingReadings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
+	weatherAPI.EXPECT().
+		Current(context.Background(), "New York").
+		Return(&models.CurrentResponse{
+			Location: models.Location{Name: "New York", Country: "United States", TzID: "America/New_York"},
+			Current: models.Current{
+				TempC:      12.2,
+				FeelslikeC: 12.2,
+				WindKph:    14.8,
+				Condition:  models.Condition{Text: "Cloudy", Code: 1006},
+				Missing:    []string{models.FieldHumidity, models.FieldPressureMb},
+			},
+		}, nil).
+		Times(2)
+
+	svc := New(nil, weatherAPI, nil, nil)
+
+	data, err := svc.Weather().Current(context.Background(), "New York", units.Metric, "en", services.OutputText)
+	require.NoError(t, err)
+
+	assert.Contains(t, data.Summary, "💧 Humidity: —\n")
+	assert.Contains(t, data.Summary, "💨 Wind Speed: 15 km/h\n")
+	assert.Contains(t, data.Summary, "🧭 Pressure: —\n")
+
+	data, err = svc.Weather().Current(context.Background(), "New York", units.Metric, "en", services.OutputJSON)
+	require.NoError(t, err)
+
+	assert.Contains(t, data.JSON, `"missing":["humidity","pressure_mb"]`)
+	assert.Contains(t, data.JSON, `"pressure":"—"`)
+}
+
+func TestGetWeatherTrend(t *testing.T) {
+	testCases := map[string]struct {
+		lang  string
+		tempC float64
+		code  int64
+		wait  string
+	}{
+		"hot_and_clear": {
+			lang:  "en",
+			tempC: 28,
+			code:  1000,
+			wait:  "📈 Perfect summer weather - great for outdoor activities!",
+		},
+		"cool_and_overcast": {
+			lang:  "en",
+			tempC: 6,
+			code:  1009,
+			wait:  "📉 Cool and overcast - indoor activities recommended",
+		},
+		"drizzle_is_rainy": {
+			lang:  "en",
+			tempC: 14,
+			code:  1150,
+			wait:  "🌧️ Rainy conditions - bring protection and plan indoor activities",
+		},
+		"unknown_code": {
+			lang:  "en",
+			tempC: 14,
+			wait:  "🌤️ Moderate conditions - suitable for most activities",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, getWeatherTrend(i18n.For(tc.lang), tc.tempC, tc.code))
+		})
+	}
+}
+
+func TestObservationText(t *testing.T) {
+	now := time.Date(2025, time.April, 11, 12, 7, 27, 0, time.UTC)
+
+	testCases := map[string]struct {
+		lang     string
+		tzID     string
+		observed time.Time
+		wait     string
+	}{
+		"unknown_time": {
+			lang: "en",
+			tzID: "Europe/London",
+			wait: "",
+		},
+		"just_now": {
+			lang:     "en",
+			tzID:     "Europe/London",
+			observed: now.Add(-30 * time.Second),
+			wait:     "2025-04-11 13:06 BST (just now)",
+		},
+		"clock_ahead": {
+			lang:     "en",
+			tzID:     "Europe/London",
+			observed: now.Add(5 * time.Minute),
+			wait:     "2025-04-11 13:12 BST (just now)",
+		},
+		"hours": {
+			lang:     "en",
+			tzID:     "Asia/Tokyo",
+			observed: now.Add(-2*time.Hour - 15*time.Minute),
+			wait:     "2025-04-11 18:52 JST (2 h 15 min ago)",
+		},
+		"unknown_zone": {
+			lang:     "de",
+			observed: now.Add(-20 * time.Minute),
+			wait:     "2025-04-11 11:47 UTC (vor 20 Min.)",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := &models.CurrentResponse{Location: models.Location{TzID: tc.tzID}}
+			if !tc.observed.IsZero() {
+				data.Current.LastUpdatedEpoch = tc.observed.Unix()
+			}
+
+			assert.Equal(t, tc.wait, newObservation(data, now).text(i18n.For(tc.lang)))
+		})
+	}
+}
+
+func TestForecast(t *testing.T) {
+	testCases := map[string]struct {
+		city            string
+		units           units.Set
+		errString       string
+		wait            string
+		setupWeatherAPI func(weatherAPI *mock.MockWeatherAPIProvider)
+	}{
+		"city_not_found": {
+			city:      "Tokyo",
+			units:     units.Metric,
+			errString: "weather API not available. Code: 400",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Forecast(context.Background(), "Tokyo", 2).
+					Return(nil, errors.New("weather API not available. Code: 400"))
+			},
+		},
+		"metric_units": {
+			city:  "London",
+			units: units.Metric,
+			wait:  "London, United Kingdom [2025-04-11 Sunny 20°C 7°C 14 km/h 2.5 mm 10]",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Forecast(context.Background(), "London", 2).
+					Return(forecastResponse(), nil)
+			},
+		},
+		"imperial_units": {
+			city:  "London",
+			units: units.Imperial,
+			wait:  "London, United Kingdom [2025-04-11 Sunny 67°F 45°F 9 mph 0.10 in 10]",
+			setupWeatherAPI: func(weatherAPI *mock.MockWeatherAPIProvider) {
+				weatherAPI.EXPECT().
+					Forecast(context.Background(), "London", 2).
+					Return(forecastResponse(), nil)
+			},
+		},
+	}
+
+	renderer, err := template.New("forecast.html").Parse(
+		"{{ .Location }} " +
+			"{{ range .Days }}[{{ .Date }} {{ .Condition }} {{ .MaxTemp }} " +
+			"{{ .MinTemp }} {{ .MaxWind }} {{ .Precipitation }} {{ .ChanceOfRain }}]{{ end }}")
 	require.NoError(t, err)
 
 	ctrl := gomock.NewController(t)
@@ -66,7 +481,7 @@ func TestCurrentWeather(t *testing.T) {
 
 	weatherAPI := mock.NewMockWeatherAPIProvider(ctrl)
 
-	svc := New(renderer, weatherAPI)
+	svc := New(renderer, weatherAPI, nil, nil)
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -74,7 +489,7 @@ func TestCurrentWeather(t *testing.T) {
 				tc.setupWeatherAPI(weatherAPI)
 			}
 
-			data, err := svc.Weather().Current(context.Background(), tc.city)
+			data, err := svc.Weather().Forecast(context.Background(), tc.city, 2, tc.units)
 			if err != nil {
 				assert.EqualError(t, err, tc.errString)
 			}
@@ -83,3 +498,33 @@ func TestCurrentWeather(t *testing.T) {
 		})
 	}
 }
+
+func forecastResponse() *models.ForecastResponse {
+	return &models.ForecastResponse{
+		Location: models.Location{
+			Name:    "London",
+			Country: "United Kingdom",
+		},
+		Forecast: models.Forecast{
+			ForecastDay: []models.ForecastDay{
+				{
+					Date: "2025-04-11",
+					Day: models.Day{
+						MaxtempC:      19.6,
+						MaxtempF:      67.3,
+						MintempC:      7.2,
+						MintempF:      45,
+						MaxwindKph:    13.7,
+						MaxwindMph:    8.6,
+						TotalprecipMm: 2.5,
+						ChanceOfRain:  10,
+						Condition: models.Condition{
+							Text: "Sunny",
+							Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// timeZone returns the location's time zone, or UTC if it is unknown.
+func timeZone(location models.Location) *time.Location {
+	if location.TzID == "" {
+		return time.UTC
+	}
+
+	zone, err := time.LoadLocation(location.TzID)
+	if err != nil {
+		return time.UTC
+	}
+
+	return zone
+}
+
+// localDate returns the date at the location at the instant now, as
+// midnight UTC like the dates passed to the providers.
+func localDate(location models.Location, now time.Time) time.Time {
+	year, month, day := now.In(timeZone(location)).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
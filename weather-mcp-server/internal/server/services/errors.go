@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+// ErrNoAirQuality and ErrNoMarineData report a location the provider has
+// no such data for, which is an answer rather than a fault.
+var (
+	ErrNoAirQuality = errors.New("air quality data is not available for this location")
+	ErrNoMarineData = errors.New("marine data is not available for this location")
+)
+
+// knownErrors are the service errors the user can act on, with the message
+// shown to them instead of the raw provider response.
+var knownErrors = []struct {
+	err     error
+	message string
+}{
+	{weatherapi.ErrInvalidKey, "The weather API key is missing or invalid, check the server configuration"},
+	{weatherapi.ErrQuotaExceeded, "The weather API key has used up its monthly quota, try again next month"},
+	{weatherapi.ErrAccessDenied, "The weather API key is disabled or cannot access this data"},
+	{weatherapi.ErrBudgetLow, "The weather API call budget is nearly used up and nothing is cached for this request, check quota_status or try again later"},
+	{weatherapi.ErrBadRequest, "The weather API rejected the request, check the arguments"},
+	{weatherapi.ErrUnavailable, "The weather API is temporarily unavailable, try again later"},
+	{context.DeadlineExceeded, "The weather API did not respond in time, try again later"},
+	{ErrNoAirQuality, "No air quality data is available for this location, try a nearby city"},
+	{ErrNoMarineData, "No marine data is available for this location, try a place on the coast"},
+	{errors.ErrUnsupported, "The configured weather provider does not offer this data"},
+}
+
+// GenericErrorMessage is shown for an error that is a server fault, where
+// the raw error would only leak provider details.
+const GenericErrorMessage = "The weather could not be fetched because of a server error, try again later"
+
+// ErrorMessage returns the message to show the user for an error they can
+// act on. ok is false for other errors, which are server faults.
+func ErrorMessage(err error) (message string, ok bool) {
+	if errors.Is(err, weatherapi.ErrLocationNotFound) {
+		return locationMessage(err), true
+	}
+
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			return known.message, true
+		}
+	}
+
+	return "", false
+}
+
+func locationMessage(err error) string {
+	var locationErr *weatherapi.LocationError
+	if !errors.As(err, &locationErr) {
+		return "No location matches the query, check the spelling or try coordinates"
+	}
+
+	if len(locationErr.Suggestions) == 0 {
+		return fmt.Sprintf("No location matches %q, check the spelling or try coordinates", locationErr.Query)
+	}
+
+	return fmt.Sprintf("No location matches %q, did you mean %s?",
+		locationErr.Query, strings.Join(locationErr.Suggestions, " or "))
+}
@@ -2,12 +2,44 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
 	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
 )
 
 //go:generate mockgen --source external.go --destination mock/external_mock.go --package mock
 
 type WeatherAPIProvider interface {
-	Current(ctx context.Context, city string) (*models.CurrentResponse, error)
+	Current(ctx context.Context, city string, opts ...weatherapi.Option) (*models.CurrentResponse, error)
+	Forecast(ctx context.Context, city string, days int, opts ...weatherapi.Option) (*models.ForecastResponse, error)
+	Alerts(ctx context.Context, city string) (*models.AlertsResponse, error)
+}
+
+// WeatherHistoryProvider is implemented by providers that serve past weather.
+type WeatherHistoryProvider interface {
+	History(ctx context.Context, city string, date, endDate time.Time) (*models.HistoryResponse, error)
+}
+
+// LocationSearcher is implemented by providers that can list the places
+// matching a query.
+type LocationSearcher interface {
+	Search(ctx context.Context, query string) (models.SearchResponse, error)
+}
+
+// AstronomyProvider is implemented by providers that serve sun and moon
+// times for a date.
+type AstronomyProvider interface {
+	Astronomy(ctx context.Context, city string, date time.Time) (*models.AstronomyResponse, error)
+}
+
+// MarineProvider is implemented by providers that serve marine forecasts
+// with waves, swell, water temperature and tides.
+type MarineProvider interface {
+	Marine(ctx context.Context, query string, days int) (*models.MarineResponse, error)
+}
+
+// QuotaProvider reports the upstream call budget.
+type QuotaProvider interface {
+	Status() weatherapi.QuotaStatus
 }
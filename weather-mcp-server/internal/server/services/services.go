@@ -1,13 +1,52 @@
 package services
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+)
 
 //go:generate mockgen --source services.go --destination mock/mock.go --package mock
 
 type Services interface {
 	Weather() WeatherService
+	Quota() QuotaService
 }
 
 type WeatherService interface {
-	Current(ctx context.Context, city string) (string, error)
+	Current(ctx context.Context, city string, system units.Set, lang string, output Output) (*StructuredResult, error)
+	Compare(ctx context.Context, cities []string, system units.Set, lang string, output Output) (*StructuredResult, error)
+	Forecast(ctx context.Context, city string, days int, system units.Set) (string, error)
+	AirQuality(ctx context.Context, city string) (string, error)
+	Astronomy(ctx context.Context, city string, date, endDate time.Time) (*StructuredResult, error)
+	Marine(ctx context.Context, city string, days int, system units.Set) (*StructuredResult, error)
+	Alerts(ctx context.Context, city string) (*StructuredResult, error)
+	SearchLocations(ctx context.Context, query string) (*StructuredResult, error)
+}
+
+type QuotaService interface {
+	Status(ctx context.Context) (*StructuredResult, error)
+}
+
+// StructuredResult is a human-readable summary along with the same data as JSON.
+type StructuredResult struct {
+	Summary string
+	JSON    string
+}
+
+// Output is the format the current weather and comparisons are rendered in.
+type Output string
+
+const (
+	OutputHTML     Output = "html"
+	OutputMarkdown Output = "markdown"
+	OutputJSON     Output = "json"
+	OutputText     Output = "text"
+)
+
+// Outputs returns the formats accepted by WeatherService.Current and
+// WeatherService.Compare.
+func Outputs() []string {
+	return []string{string(OutputHTML), string(OutputMarkdown), string(OutputJSON), string(OutputText)}
 }
@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func AirQuality(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("air_quality",
+		mcp.WithDescription(`
+			The service retrieves the current air quality for a specified city and returns only HTML and CSS code designed 
+			for displaying it directly on a canvas. No additional comments should be included after the response. 
+			It reports measured PM2.5, PM10, ozone, nitrogen dioxide, sulphur dioxide and carbon monoxide concentrations, 
+			the US EPA air quality index with its health band and advice, and the UK DEFRA band. 
+			This HTML must be rendered visually on the canvas.
+		`),
+		mcp.WithString("city",
+			mcp.Required(),
+			mcp.Description(`
+				The name of the city. This field is required and must be provided in English. 
+				Only one city is allowed, and it must be the last one provided by the user.
+			`),
+		),
+	)
+
+	handler := handlers.AirQuality(svc)
+
+	return tool, handler
+}
@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAirQuality(t *testing.T) {
+	tool, handler := AirQuality(nil)
+
+	assert.Equal(t, "air_quality", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"city"})
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Alerts(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("weather_alerts",
+		mcp.WithDescription(`
+			The service retrieves the active government weather alerts for a specified city. 
+			It returns a readable summary of each alert (headline, event, severity, urgency, affected areas, 
+			effective and expiry times and instructions) together with the same data as a JSON resource. 
+			If there are no active alerts, the summary says so.
+		`),
+		mcp.WithString("city",
+			mcp.Required(),
+			mcp.Description(`
+				The name of the city. This field is required and must be provided in English. 
+				Only one city is allowed, and it must be the last one provided by the user.
+			`),
+		),
+	)
+
+	handler := handlers.Alerts(svc)
+
+	return tool, handler
+}
@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlerts(t *testing.T) {
+	tool, handler := Alerts(nil)
+
+	assert.Equal(t, "weather_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"city"})
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Astronomy(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("astronomy",
+		mcp.WithDescription(`
+			The service retrieves sun and moon data for a place on a date or a range of up to 7 days. 
+			For each day it returns the sunrise, sunset, moonrise and moonset in the local time of the place, 
+			the moon phase and illumination and the length of the day, as a readable summary 
+			together with the same data as a JSON resource.
+		`),
+		mcp.WithString("city",
+			mcp.Description(cityDescription),
+		),
+		mcp.WithObject("location",
+			mcp.Description(locationDescription),
+			mcp.Properties(locationProperties),
+		),
+		mcp.WithString("date",
+			mcp.Description("The day as YYYY-MM-DD, such as 2025-06-21. Defaults to today at the place."),
+		),
+		mcp.WithString("end_date",
+			mcp.Description("The last day of a range starting at date, as YYYY-MM-DD, at most 6 days after date."),
+		),
+	)
+
+	handler := handlers.Astronomy(svc)
+
+	return tool, handler
+}
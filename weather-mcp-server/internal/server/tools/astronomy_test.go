@@ -0,0 +1,21 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAstronomy(t *testing.T) {
+	tool, handler := Astronomy(nil)
+
+	assert.Equal(t, "astronomy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.Contains(t, tool.InputSchema.Properties, "location")
+	assert.Contains(t, tool.InputSchema.Properties, "date")
+	assert.Contains(t, tool.InputSchema.Properties, "end_date")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Compare(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("compare_weather",
+		mcp.WithDescription(`
+			The service retrieves the current weather for several cities at once and shows them side by side 
+			in one table: condition, temperature, feels like, humidity, wind speed, pressure and local time. 
+			Use it instead of calling current_weather once per city. A city that cannot be found or fetched 
+			is reported in its own row, and the others are still returned. Unless another output is requested, 
+			it returns only HTML and CSS code that must be rendered visually on the canvas.
+		`),
+		mcp.WithArray("cities",
+			mcp.Required(),
+			mcp.Description(`
+				The cities to compare, from 1 to 10, each in English. Like the city argument of current_weather, 
+				an entry may also be an id from search_locations such as id:2801268, or coordinates as lat,lon.
+			`),
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.MinItems(1),
+			mcp.MaxItems(10),
+		),
+		unitsOption(),
+		windUnitOption(),
+		mcp.WithString("lang",
+			mcp.Description("The language of the text in the response: en, es, fr, de or ja. Defaults to en."),
+			mcp.Enum(i18n.Languages()...),
+		),
+		mcp.WithString("output",
+			mcp.Description(`
+				The format of the response: html (the default), markdown, text, or json for the data alone. 
+				Except for json, the same data is embedded as a JSON resource, with the weather of each city 
+				following the schema at weather://schema/current_weather/v1.
+			`),
+			mcp.Enum(services.Outputs()...),
+		),
+	)
+
+	handler := handlers.Compare(svc)
+
+	return tool, handler
+}
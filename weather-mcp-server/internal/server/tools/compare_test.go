@@ -0,0 +1,21 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tool, handler := Compare(nil)
+
+	assert.Equal(t, "compare_weather", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "cities")
+	assert.Contains(t, tool.InputSchema.Properties, "units")
+	assert.Contains(t, tool.InputSchema.Properties, "lang")
+	assert.Contains(t, tool.InputSchema.Properties, "output")
+	assert.Equal(t, []string{"cities"}, tool.InputSchema.Required)
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func Marine(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("marine_conditions",
+		mcp.WithDescription(`
+			The service retrieves the marine forecast for a coast or sea area: wave height, swell height, 
+			period and direction, wind and gusts, water temperature and the times of high and low tide. 
+			For each day it gives a verdict of good, fair, poor or dangerous for sailing and for swimming 
+			in daylight, with the reasons, as a readable summary together with the same data as a JSON resource.
+		`),
+		mcp.WithString("city",
+			mcp.Description(cityDescription),
+		),
+		mcp.WithObject("location",
+			mcp.Description(locationDescription),
+			mcp.Properties(locationProperties),
+		),
+		mcp.WithNumber("days",
+			mcp.Description("The number of forecast days, from 1 to 7. Defaults to 1."),
+			mcp.Min(1),
+			mcp.Max(7),
+		),
+		unitsOption(),
+		windUnitOption(),
+	)
+
+	handler := handlers.Marine(svc)
+
+	return tool, handler
+}
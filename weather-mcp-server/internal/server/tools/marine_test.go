@@ -0,0 +1,21 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarine(t *testing.T) {
+	tool, handler := Marine(nil)
+
+	assert.Equal(t, "marine_conditions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.Contains(t, tool.InputSchema.Properties, "location")
+	assert.Contains(t, tool.InputSchema.Properties, "days")
+	assert.Contains(t, tool.InputSchema.Properties, "units")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func QuotaStatus(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("quota_status",
+		mcp.WithDescription(`
+			The service reports how many upstream weather API calls have been used today and this month, 
+			how many remain and whether the server has switched to serving cached data only 
+			because the budget is nearly used up. It returns a readable summary together with the same data as JSON.
+		`),
+	)
+
+	handler := handlers.QuotaStatus(svc)
+
+	return tool, handler
+}
@@ -0,0 +1,17 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaStatus(t *testing.T) {
+	tool, handler := QuotaStatus(nil)
+
+	assert.Equal(t, "quota_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	assert.NotNil(t, handler)
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+)
+
+func SearchLocations(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("search_locations",
+		mcp.WithDescription(`
+			The service lists the places matching a name, so that places sharing a name, such as 
+			Springfield or Portland, can be told apart. Each place has its region, country, 
+			coordinates and a stable id. It returns a readable list together with the same data 
+			as a JSON resource. Pass the id of the intended place, such as id:2801268, as the city 
+			of current_weather to get the weather for exactly that place.
+		`),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The name of the place to search for, in English, optionally with its region or country."),
+		),
+	)
+
+	handler := handlers.SearchLocations(svc)
+
+	return tool, handler
+}
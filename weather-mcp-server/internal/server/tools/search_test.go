@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchLocations(t *testing.T) {
+	tool, handler := SearchLocations(nil)
+
+	assert.Equal(t, "search_locations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	assert.NotNil(t, handler)
+}
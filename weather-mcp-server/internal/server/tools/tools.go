@@ -5,6 +5,68 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
 )
 
 type ToolFunc func(svc services.Services) (mcp.Tool, server.ToolHandlerFunc)
+
+// cityDescription, locationDescription and locationProperties describe the
+// city and location arguments of the tools that accept either.
+const (
+	cityDescription = `
+		The name of the city. Either city or location is required, and the city must be provided in English. 
+		Only one city is allowed, and it must be the last one provided by the user. 
+		To pin down a place whose name is shared by several, pass an id from search_locations 
+		such as id:2801268, or coordinates as lat,lon such as 48.86,2.35.
+	`
+	locationDescription = `
+		A place given other than by its name, as an alternative to city. Set exactly one of: 
+		lat and lon together, postcode, airport or ip.
+	`
+)
+
+var locationProperties = map[string]any{
+	"lat": map[string]any{
+		"type":        "number",
+		"description": "Latitude in degrees, from -90 to 90.",
+		"minimum":     -90,
+		"maximum":     90,
+	},
+	"lon": map[string]any{
+		"type":        "number",
+		"description": "Longitude in degrees, from -180 to 180.",
+		"minimum":     -180,
+		"maximum":     180,
+	},
+	"postcode": map[string]any{
+		"type":        "string",
+		"description": "A US zip code, UK postcode or Canadian postal code, such as 10001 or SW1A 1AA.",
+	},
+	"airport": map[string]any{
+		"type":        "string",
+		"description": "A 3-letter IATA airport code such as LHR, or a 4-letter ICAO code such as EGLL.",
+	},
+	"ip": map[string]any{
+		"type":        "string",
+		"description": "An IPv4 or IPv6 address, or auto for the server's own public address.",
+	},
+}
+
+// unitsOption and windUnitOption are the units and wind_unit arguments of
+// the tools that report measurements.
+func unitsOption() mcp.ToolOption {
+	return mcp.WithString("units",
+		mcp.Description(`
+			The unit system: metric (°C, km/h, mb, mm, m), imperial (°F, mph, inHg, in, ft), 
+			uk (°C, mph, mb, mm, m) or scientific (K, m/s, hPa, mm, m). Defaults to metric.
+		`),
+		mcp.Enum(units.Systems()...),
+	)
+}
+
+func windUnitOption() mcp.ToolOption {
+	return mcp.WithString("wind_unit",
+		mcp.Description("Overrides the wind speed unit of the unit system: kph, mph, mps, knots or beaufort."),
+		mcp.Enum(units.WindUnits()...),
+	)
+}
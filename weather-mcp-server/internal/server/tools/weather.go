@@ -5,17 +5,54 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/TuanKiri/weather-mcp-server/internal/server/handlers"
+	"github.com/TuanKiri/weather-mcp-server/internal/server/i18n"
 	"github.com/TuanKiri/weather-mcp-server/internal/server/services"
 )
 
 func CurrentWeather(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
 	tool := mcp.NewTool("current_weather",
 		mcp.WithDescription(`
-			The service retrieves the current weather for a specified city and returns only HTML and CSS code designed 
+			The service retrieves the current weather for a specified city and, unless another output is requested, returns only HTML and CSS code designed 
 			for displaying weather information directly on a canvas. No additional comments should be included after the response. 
 			It processes the city's name, gathers weather data such as temperature, humidity, wind speed, and general weather conditions, 
 			and then generates a structured HTML layout along with appropriate CSS styles. This HTML must be rendered visually on the canvas. 
-			After receiving the response, the text inside the HTML should be translated into the language of the request.
+			Pass lang to receive the text inside the HTML in the language of the request.
+		`),
+		mcp.WithString("city",
+			mcp.Description(cityDescription),
+		),
+		mcp.WithObject("location",
+			mcp.Description(locationDescription),
+			mcp.Properties(locationProperties),
+		),
+		unitsOption(),
+		windUnitOption(),
+		mcp.WithString("lang",
+			mcp.Description("The language of the text in the response: en, es, fr, de or ja. Defaults to en."),
+			mcp.Enum(i18n.Languages()...),
+		),
+		mcp.WithString("output",
+			mcp.Description(`
+				The format of the response: html (the default), markdown, text, or json for the data alone. 
+				Except for json, the same data is embedded as a JSON resource following the schema 
+				at weather://schema/current_weather/v1.
+			`),
+			mcp.Enum(services.Outputs()...),
+		),
+	)
+
+	handler := handlers.CurrentWeather(svc)
+
+	return tool, handler
+}
+
+func Forecast(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("forecast_weather",
+		mcp.WithDescription(`
+			The service retrieves a daily weather forecast for a specified city and returns only HTML and CSS code designed 
+			for displaying the forecast directly on a canvas. No additional comments should be included after the response. 
+			For each day it shows the date, general weather conditions, maximum and minimum temperature, chance of rain 
+			and maximum wind speed. This HTML must be rendered visually on the canvas.
 		`),
 		mcp.WithString("city",
 			mcp.Required(),
@@ -24,9 +61,16 @@ func CurrentWeather(svc services.Services) (mcp.Tool, server.ToolHandlerFunc) {
 				Only one city is allowed, and it must be the last one provided by the user.
 			`),
 		),
+		mcp.WithNumber("days",
+			mcp.Description("The number of forecast days, from 1 to 14. Defaults to 3."),
+			mcp.Min(1),
+			mcp.Max(14),
+		),
+		unitsOption(),
+		windUnitOption(),
 	)
 
-	handler := handlers.CurrentWeather(svc)
+	handler := handlers.Forecast(svc)
 
 	return tool, handler
 }
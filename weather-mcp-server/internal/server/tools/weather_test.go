@@ -12,6 +12,24 @@ func TestCurrentWeather(t *testing.T) {
 	assert.Equal(t, "current_weather", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.Contains(t, tool.InputSchema.Properties, "location")
+	assert.Contains(t, tool.InputSchema.Properties, "units")
+	assert.Contains(t, tool.InputSchema.Properties, "wind_unit")
+	assert.Contains(t, tool.InputSchema.Properties, "lang")
+	assert.Contains(t, tool.InputSchema.Properties, "output")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	assert.NotNil(t, handler)
+}
+
+func TestForecast(t *testing.T) {
+	tool, handler := Forecast(nil)
+
+	assert.Equal(t, "forecast_weather", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "city")
+	assert.Contains(t, tool.InputSchema.Properties, "days")
+	assert.Contains(t, tool.InputSchema.Properties, "units")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"city"})
 
 	assert.NotNil(t, handler)
@@ -0,0 +1,175 @@
+// Package airquality turns raw pollutant concentrations into US EPA
+// air quality index values and health bands.
+package airquality
+
+import (
+	"math"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type Category int
+
+const (
+	Good Category = iota + 1
+	Moderate
+	UnhealthyForSensitiveGroups
+	Unhealthy
+	VeryUnhealthy
+	Hazardous
+)
+
+func (c Category) String() string {
+	switch c {
+	case Good:
+		return "Good"
+	case Moderate:
+		return "Moderate"
+	case UnhealthyForSensitiveGroups:
+		return "Unhealthy for Sensitive Groups"
+	case Unhealthy:
+		return "Unhealthy"
+	case VeryUnhealthy:
+		return "Very Unhealthy"
+	case Hazardous:
+		return "Hazardous"
+	default:
+		return "Unknown"
+	}
+}
+
+// Advice returns the EPA health message for the category.
+func (c Category) Advice() string {
+	switch c {
+	case Good:
+		return "Air quality is satisfactory. Enjoy outdoor activities."
+	case Moderate:
+		return "Unusually sensitive people should consider reducing prolonged or heavy exertion outdoors."
+	case UnhealthyForSensitiveGroups:
+		return "Children, older adults and people with heart or lung disease should reduce prolonged or heavy exertion outdoors."
+	case Unhealthy:
+		return "Everyone should reduce prolonged or heavy exertion; sensitive groups should avoid it."
+	case VeryUnhealthy:
+		return "Everyone should avoid prolonged or heavy exertion outdoors; sensitive groups should stay indoors."
+	case Hazardous:
+		return "Health warning of emergency conditions. Everyone should avoid all outdoor physical activity."
+	default:
+		return "No health guidance available."
+	}
+}
+
+// breakpoint maps a concentration range onto an index range.
+type breakpoint struct {
+	cLow, cHigh float64
+	iLow, iHigh int
+}
+
+// US EPA breakpoints for 24-hour PM2.5 (2024 revision) and PM10, μg/m³.
+var (
+	pm25Breakpoints = []breakpoint{
+		{0, 9.0, 0, 50},
+		{9.1, 35.4, 51, 100},
+		{35.5, 55.4, 101, 150},
+		{55.5, 125.4, 151, 200},
+		{125.5, 225.4, 201, 300},
+		{225.5, 325.4, 301, 500},
+	}
+	pm10Breakpoints = []breakpoint{
+		{0, 54, 0, 50},
+		{55, 154, 51, 100},
+		{155, 254, 101, 150},
+		{255, 354, 151, 200},
+		{355, 424, 201, 300},
+		{425, 604, 301, 500},
+	}
+)
+
+// PM25Index returns the US EPA AQI value for a PM2.5 concentration.
+func PM25Index(concentration float64) int {
+	return index(pm25Breakpoints, math.Floor(concentration*10)/10)
+}
+
+// PM10Index returns the US EPA AQI value for a PM10 concentration.
+func PM10Index(concentration float64) int {
+	return index(pm10Breakpoints, math.Floor(concentration))
+}
+
+func index(breakpoints []breakpoint, concentration float64) int {
+	if concentration <= 0 {
+		return 0
+	}
+
+	for _, bp := range breakpoints {
+		if concentration <= bp.cHigh {
+			value := float64(bp.iHigh-bp.iLow)/(bp.cHigh-bp.cLow)*(concentration-bp.cLow) + float64(bp.iLow)
+			return int(math.Round(value))
+		}
+	}
+
+	return breakpoints[len(breakpoints)-1].iHigh
+}
+
+// CategoryOf returns the health band for an AQI value.
+func CategoryOf(aqi int) Category {
+	switch {
+	case aqi <= 50:
+		return Good
+	case aqi <= 100:
+		return Moderate
+	case aqi <= 150:
+		return UnhealthyForSensitiveGroups
+	case aqi <= 200:
+		return Unhealthy
+	case aqi <= 300:
+		return VeryUnhealthy
+	default:
+		return Hazardous
+	}
+}
+
+// DEFRABand returns the UK DEFRA band name for a 1-10 index.
+func DEFRABand(index int64) string {
+	switch {
+	case index <= 0:
+		return "Unknown"
+	case index <= 3:
+		return "Low"
+	case index <= 6:
+		return "Moderate"
+	case index <= 9:
+		return "High"
+	default:
+		return "Very High"
+	}
+}
+
+type Assessment struct {
+	Index     int
+	Category  Category
+	Dominant  string
+	DEFRABand string
+}
+
+// Assess computes the AQI from particulate concentrations. The category is
+// the worse of the computed band and the provider's US EPA index, which also
+// accounts for gaseous pollutants.
+func Assess(data models.AirQuality) Assessment {
+	assessment := Assessment{
+		Index:     PM25Index(data.PM25),
+		Dominant:  "PM2.5",
+		DEFRABand: DEFRABand(data.GBDEFRAIndex),
+	}
+
+	if pm10 := PM10Index(data.PM10); pm10 > assessment.Index {
+		assessment.Index = pm10
+		assessment.Dominant = "PM10"
+	}
+
+	assessment.Category = CategoryOf(assessment.Index)
+
+	if epa := Category(data.USEPAIndex); epa > assessment.Category && epa <= Hazardous {
+		assessment.Category = epa
+	}
+
+	return assessment
+}
@@ -0,0 +1,74 @@
+package airquality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func TestPM25Index(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		concentration float64
+		wait          int
+	}{
+		"zero":             {concentration: 0, wait: 0},
+		"good_upper":       {concentration: 9.0, wait: 50},
+		"moderate_lower":   {concentration: 9.1, wait: 51},
+		"truncated":        {concentration: 12.08, wait: 56},
+		"unhealthy":        {concentration: 80, wait: 168},
+		"beyond_the_scale": {concentration: 900, wait: 500},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, PM25Index(tc.concentration))
+		})
+	}
+}
+
+func TestPM10Index(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		concentration float64
+		wait          int
+	}{
+		"good_upper":     {concentration: 54.9, wait: 50},
+		"moderate_lower": {concentration: 55, wait: 51},
+		"sensitive":      {concentration: 200, wait: 123},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, PM10Index(tc.concentration))
+		})
+	}
+}
+
+func TestAssess(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data models.AirQuality
+		wait Assessment
+	}{
+		"particulates_dominate": {
+			data: models.AirQuality{PM25: 5.2, PM10: 120, USEPAIndex: 1, GBDEFRAIndex: 2},
+			wait: Assessment{Index: 83, Category: Moderate, Dominant: "PM10", DEFRABand: "Low"},
+		},
+		"provider_index_is_worse": {
+			data: models.AirQuality{PM25: 5.2, PM10: 10, USEPAIndex: 3, GBDEFRAIndex: 7},
+			wait: Assessment{Index: 29, Category: UnhealthyForSensitiveGroups, Dominant: "PM2.5", DEFRABand: "High"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, Assess(tc.data))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Package astro works out the position of the sun, sunrise and sunset and
+// the phase of the moon from coordinates and a time alone, so that these
+// can be answered without a weather provider. The sun follows the NOAA
+// solar calculator and the moon the low-precision formulas of Meeus,
+// Astronomical Algorithms, chapter 48.
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// j2000 is the Julian day of 2000-01-01 12:00 UTC.
+const j2000 = 2451545.0
+
+// julianCentury returns the Julian centuries from J2000 to t.
+func julianCentury(t time.Time) float64 {
+	days := float64(t.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5
+
+	return (days - j2000) / 36525
+}
+
+func sin(degrees float64) float64 {
+	return math.Sin(degrees * math.Pi / 180)
+}
+
+func cos(degrees float64) float64 {
+	return math.Cos(degrees * math.Pi / 180)
+}
+
+func tan(degrees float64) float64 {
+	return math.Tan(degrees * math.Pi / 180)
+}
+
+func asin(x float64) float64 {
+	return math.Asin(x) * 180 / math.Pi
+}
+
+func acos(x float64) float64 {
+	return math.Acos(x) * 180 / math.Pi
+}
+
+// normalize returns degrees in the range [0, 360).
+func normalize(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+
+	return degrees
+}
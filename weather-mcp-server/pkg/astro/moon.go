@@ -0,0 +1,80 @@
+package astro
+
+import (
+	"time"
+)
+
+type Phase int
+
+const (
+	NewMoon Phase = iota
+	WaxingCrescent
+	FirstQuarter
+	WaxingGibbous
+	FullMoon
+	WaningGibbous
+	LastQuarter
+	WaningCrescent
+)
+
+// String returns the name of the phase as WeatherAPI writes it.
+func (p Phase) String() string {
+	switch p {
+	case NewMoon:
+		return "New Moon"
+	case WaxingCrescent:
+		return "Waxing Crescent"
+	case FirstQuarter:
+		return "First Quarter"
+	case WaxingGibbous:
+		return "Waxing Gibbous"
+	case FullMoon:
+		return "Full Moon"
+	case WaningGibbous:
+		return "Waning Gibbous"
+	case LastQuarter:
+		return "Last Quarter"
+	case WaningCrescent:
+		return "Waning Crescent"
+	default:
+		return ""
+	}
+}
+
+// Moon is the phase of the moon at an instant.
+type Moon struct {
+	Phase Phase
+	// Illumination is the lit fraction of the disc, from 0 to 1.
+	Illumination float64
+	// Angle is how far the moon is through its cycle in degrees, 0 at new
+	// moon, 90 at first quarter, 180 at full moon and 270 at last quarter.
+	Angle float64
+}
+
+// MoonAt returns the phase of the moon at the instant t. It is the same
+// everywhere on Earth.
+func MoonAt(t time.Time) Moon {
+	jc := julianCentury(t)
+
+	elongation := normalize(297.8501921 + jc*(445267.1114034+jc*(-0.0018819+jc*(1/545868.0-jc/113065000.0))))
+	sunAnomaly := normalize(357.5291092 + jc*(35999.0502909+jc*(-0.0001536+jc/24490000.0)))
+	moonAnomaly := normalize(134.9633964 + jc*(477198.8675055+jc*(0.0087414+jc*(1/69699.0-jc/14712000.0))))
+
+	phaseAngle := 180 - elongation -
+		6.289*sin(moonAnomaly) +
+		2.100*sin(sunAnomaly) -
+		1.274*sin(2*elongation-moonAnomaly) -
+		0.658*sin(2*elongation) -
+		0.214*sin(2*moonAnomaly) -
+		0.110*sin(elongation)
+
+	angle := normalize(180 - phaseAngle)
+
+	return Moon{
+		// Each phase is centred on its angle, so that new moon runs from
+		// 337.5 to 22.5 degrees.
+		Phase:        Phase(int(normalize(angle+22.5)/45) % 8),
+		Illumination: (1 + cos(phaseAngle)) / 2,
+		Angle:        angle,
+	}
+}
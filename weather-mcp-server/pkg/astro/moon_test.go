@@ -0,0 +1,76 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoonAt(t *testing.T) {
+	t.Parallel()
+
+	// Times of the principal phases published by the US Naval Observatory.
+	testCases := map[string]struct {
+		time         time.Time
+		phase        Phase
+		illumination float64
+		angle        float64
+	}{
+		"new_moon": {
+			time:  time.Date(2024, time.April, 8, 18, 21, 0, 0, time.UTC),
+			phase: NewMoon,
+		},
+		"first_quarter": {
+			time:         time.Date(2024, time.April, 15, 19, 13, 0, 0, time.UTC),
+			phase:        FirstQuarter,
+			illumination: 0.5,
+			angle:        90,
+		},
+		"full_moon": {
+			time:         time.Date(2024, time.April, 23, 23, 49, 0, 0, time.UTC),
+			phase:        FullMoon,
+			illumination: 1,
+			angle:        180,
+		},
+		"last_quarter": {
+			time:         time.Date(2024, time.May, 1, 11, 27, 0, 0, time.UTC),
+			phase:        LastQuarter,
+			illumination: 0.5,
+			angle:        270,
+		},
+		"waning_crescent": {
+			time:         time.Date(2025, time.June, 21, 12, 0, 0, 0, time.UTC),
+			phase:        WaningCrescent,
+			illumination: 0.2,
+			angle:        306,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			moon := MoonAt(tc.time)
+
+			assert.Equal(t, tc.phase, moon.Phase)
+			assert.InDelta(t, tc.illumination, moon.Illumination, 0.02)
+			// A new moon's angle is near 0 or just below 360.
+			assert.InDelta(t, 0, angleBetween(tc.angle, moon.Angle), 1)
+		})
+	}
+}
+
+func TestPhaseString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "New Moon", NewMoon.String())
+	assert.Equal(t, "Waning Crescent", WaningCrescent.String())
+	assert.Equal(t, "", Phase(8).String())
+}
+
+func angleBetween(a, b float64) float64 {
+	diff := normalize(a - b)
+
+	return min(diff, 360-diff)
+}
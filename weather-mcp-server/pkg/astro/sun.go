@@ -0,0 +1,186 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// horizon is the elevation of the sun's centre at sunrise and sunset, in
+// degrees. It allows for refraction and the size of the sun's disc.
+const horizon = -0.833
+
+// Sun is the course of the sun over one day at a place.
+type Sun struct {
+	Sunrise time.Time
+	Noon    time.Time
+	Sunset  time.Time
+	// AlwaysUp and AlwaysDown are set in polar day and night, when there
+	// is no sunrise or sunset and both are zero.
+	AlwaysUp   bool
+	AlwaysDown bool
+}
+
+// DayLength returns the time from sunrise to sunset: a whole day in polar
+// day and zero in polar night.
+func (s Sun) DayLength() time.Duration {
+	switch {
+	case s.AlwaysUp:
+		return 24 * time.Hour
+	case s.AlwaysDown:
+		return 0
+	}
+
+	return s.Sunset.Sub(s.Sunrise)
+}
+
+// solarPosition is where the sun is at an instant, as used by the NOAA
+// calculator.
+type solarPosition struct {
+	// declination is in degrees.
+	declination float64
+	// equationOfTime is how far sundial time is ahead of mean solar
+	// time, in minutes.
+	equationOfTime float64
+}
+
+func newSolarPosition(t time.Time) solarPosition {
+	jc := julianCentury(t)
+
+	meanLong := normalize(280.46646 + jc*(36000.76983+jc*0.0003032))
+	meanAnomaly := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	eccentricity := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+
+	center := sin(meanAnomaly)*(1.914602-jc*(0.004817+0.000014*jc)) +
+		sin(2*meanAnomaly)*(0.019993-0.000101*jc) +
+		sin(3*meanAnomaly)*0.000289
+
+	omega := 125.04 - 1934.136*jc
+	apparentLong := meanLong + center - 0.00569 - 0.00478*sin(omega)
+
+	meanObliquity := 23 + (26+(21.448-jc*(46.815+jc*(0.00059-jc*0.001813)))/60)/60
+	obliquity := meanObliquity + 0.00256*cos(omega)
+
+	y := tan(obliquity/2) * tan(obliquity/2)
+
+	equationOfTime := y*sin(2*meanLong) -
+		2*eccentricity*sin(meanAnomaly) +
+		4*eccentricity*y*sin(meanAnomaly)*cos(2*meanLong) -
+		0.5*y*y*sin(4*meanLong) -
+		1.25*eccentricity*eccentricity*sin(2*meanAnomaly)
+
+	return solarPosition{
+		declination:    asin(sin(obliquity) * sin(apparentLong)),
+		equationOfTime: 4 * equationOfTime * 180 / math.Pi,
+	}
+}
+
+// SunOn returns the course of the sun at lat, lon on the calendar day of
+// date in date's time zone. The times are in that zone.
+func SunOn(lat, lon float64, date time.Time) Sun {
+	year, month, day := date.Date()
+	zone := date.Location()
+
+	noon := solarNoon(lon, time.Date(year, month, day, 12, 0, 0, 0, zone))
+
+	sun := Sun{Noon: noon.In(zone)}
+
+	switch ha, ok := hourAngle(lat, newSolarPosition(noon).declination); {
+	case ok:
+		// The sun moves in declination during the day, so each event is
+		// worked out again from the sun's position at its first estimate.
+		sunrise := noon.Add(-minutes(4 * ha))
+		sunset := noon.Add(minutes(4 * ha))
+
+		sun.Sunrise = sunEvent(lat, lon, sunrise, -1).In(zone)
+		sun.Sunset = sunEvent(lat, lon, sunset, 1).In(zone)
+	case ha > 0:
+		sun.AlwaysUp = true
+	default:
+		sun.AlwaysDown = true
+	}
+
+	return sun
+}
+
+// solarNoon returns the solar noon nearest to t.
+func solarNoon(lon float64, t time.Time) time.Time {
+	for range 2 {
+		midnight := t.UTC().Truncate(24 * time.Hour)
+		noon := midnight.Add(minutes(720 - 4*lon - newSolarPosition(t).equationOfTime))
+
+		switch diff := noon.Sub(t); {
+		case diff > 12*time.Hour:
+			noon = noon.Add(-24 * time.Hour)
+		case diff < -12*time.Hour:
+			noon = noon.Add(24 * time.Hour)
+		}
+
+		t = noon
+	}
+
+	return t
+}
+
+// sunEvent refines the estimate of a sunrise, with direction -1, or a
+// sunset, with direction 1.
+func sunEvent(lat, lon float64, estimate time.Time, direction float64) time.Time {
+	position := newSolarPosition(estimate)
+
+	ha, ok := hourAngle(lat, position.declination)
+	if !ok {
+		return estimate
+	}
+
+	midnight := estimate.UTC().Truncate(24 * time.Hour)
+	event := midnight.Add(minutes(720 - 4*lon - position.equationOfTime + direction*4*ha))
+
+	switch diff := event.Sub(estimate); {
+	case diff > 12*time.Hour:
+		event = event.Add(-24 * time.Hour)
+	case diff < -12*time.Hour:
+		event = event.Add(24 * time.Hour)
+	}
+
+	return event
+}
+
+// hourAngle returns the hour angle of sunrise in degrees. When the sun does
+// not cross the horizon, ok is false and the angle is 180 for polar day or
+// 0 for polar night.
+func hourAngle(lat, declination float64) (float64, bool) {
+	x := (sin(horizon) - sin(lat)*sin(declination)) / (cos(lat) * cos(declination))
+
+	switch {
+	case x < -1:
+		return 180, false
+	case x > 1:
+		return 0, false
+	}
+
+	return acos(x), true
+}
+
+// Elevation returns the angle of the sun's centre above the horizon at lat,
+// lon at the instant t, in degrees, without refraction.
+func Elevation(lat, lon float64, t time.Time) float64 {
+	position := newSolarPosition(t)
+
+	utc := t.UTC()
+	minutesOfDay := float64(utc.Hour()*60+utc.Minute()) + float64(utc.Second())/60
+	trueSolarTime := math.Mod(minutesOfDay+position.equationOfTime+4*lon, 1440)
+
+	ha := trueSolarTime/4 - 180
+
+	zenith := acos(sin(lat)*sin(position.declination) + cos(lat)*cos(position.declination)*cos(ha))
+
+	return 90 - zenith
+}
+
+// IsDay reports whether the sun is up at lat, lon at the instant t.
+func IsDay(lat, lon float64, t time.Time) bool {
+	return Elevation(lat, lon, t) > horizon
+}
+
+func minutes(m float64) time.Duration {
+	return time.Duration(m * float64(time.Minute))
+}
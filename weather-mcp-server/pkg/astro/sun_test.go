@@ -0,0 +1,136 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSunOn(t *testing.T) {
+	t.Parallel()
+
+	// Published sunrise and sunset times, to the minute.
+	testCases := map[string]struct {
+		lat, lon   float64
+		zone       string
+		date       string
+		sunrise    string
+		sunset     string
+		alwaysUp   bool
+		alwaysDown bool
+	}{
+		"london_midsummer": {
+			lat: 51.5074, lon: -0.1278, zone: "Europe/London", date: "2025-06-21",
+			sunrise: "04:43", sunset: "21:21",
+		},
+		"new_york_midwinter": {
+			lat: 40.7128, lon: -74.006, zone: "America/New_York", date: "2024-12-21",
+			sunrise: "07:16", sunset: "16:32",
+		},
+		"sydney_midwinter": {
+			lat: -33.8688, lon: 151.2093, zone: "Australia/Sydney", date: "2025-06-21",
+			sunrise: "07:00", sunset: "16:54",
+		},
+		"tokyo_equinox": {
+			lat: 35.6762, lon: 139.6503, zone: "Asia/Tokyo", date: "2025-03-20",
+			sunrise: "05:45", sunset: "17:53",
+		},
+		"honolulu_new_year": {
+			lat: 21.3069, lon: -157.8583, zone: "Pacific/Honolulu", date: "2025-01-01",
+			sunrise: "07:09", sunset: "18:01",
+		},
+		"tromso_midnight_sun": {
+			lat: 69.6492, lon: 18.9553, zone: "Europe/Oslo", date: "2025-06-21",
+			alwaysUp: true,
+		},
+		"tromso_polar_night": {
+			lat: 69.6492, lon: 18.9553, zone: "Europe/Oslo", date: "2025-12-21",
+			alwaysDown: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			zone, err := time.LoadLocation(tc.zone)
+			require.NoError(t, err)
+
+			date, err := time.ParseInLocation(time.DateOnly, tc.date, zone)
+			require.NoError(t, err)
+
+			sun := SunOn(tc.lat, tc.lon, date)
+
+			assert.Equal(t, tc.alwaysUp, sun.AlwaysUp)
+			assert.Equal(t, tc.alwaysDown, sun.AlwaysDown)
+			assert.Equal(t, tc.date, sun.Noon.Format(time.DateOnly))
+
+			if tc.alwaysUp || tc.alwaysDown {
+				assert.True(t, sun.Sunrise.IsZero())
+				assert.True(t, sun.Sunset.IsZero())
+				return
+			}
+
+			sunrise, err := time.ParseInLocation(time.DateOnly+" 15:04", tc.date+" "+tc.sunrise, zone)
+			require.NoError(t, err)
+
+			sunset, err := time.ParseInLocation(time.DateOnly+" 15:04", tc.date+" "+tc.sunset, zone)
+			require.NoError(t, err)
+
+			// A published minute covers the whole of that minute.
+			assert.WithinDuration(t, sunrise.Add(30*time.Second), sun.Sunrise, time.Minute)
+			assert.WithinDuration(t, sunset.Add(30*time.Second), sun.Sunset, time.Minute)
+			assert.Equal(t, zone, sun.Sunrise.Location())
+		})
+	}
+}
+
+func TestDayLength(t *testing.T) {
+	t.Parallel()
+
+	sunrise := time.Date(2025, time.June, 21, 3, 43, 0, 0, time.UTC)
+
+	assert.Equal(t, 16*time.Hour+38*time.Minute, Sun{Sunrise: sunrise, Sunset: sunrise.Add(16*time.Hour + 38*time.Minute)}.DayLength())
+	assert.Equal(t, 24*time.Hour, Sun{AlwaysUp: true}.DayLength())
+	assert.Equal(t, time.Duration(0), Sun{AlwaysDown: true}.DayLength())
+}
+
+func TestElevation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		lat, lon float64
+		time     time.Time
+		wait     float64
+		isDay    bool
+	}{
+		"london_solar_noon_midsummer": {
+			lat: 51.5074, lon: -0.1278,
+			time:  time.Date(2025, time.June, 21, 12, 2, 0, 0, time.UTC),
+			wait:  61.93,
+			isDay: true,
+		},
+		"london_midnight": {
+			lat: 51.5074, lon: -0.1278,
+			time: time.Date(2025, time.June, 21, 0, 2, 0, 0, time.UTC),
+			wait: -15.07,
+		},
+		"equator_equinox_noon": {
+			lat: 0, lon: 0,
+			time:  time.Date(2025, time.March, 20, 12, 7, 0, 0, time.UTC),
+			wait:  89.98,
+			isDay: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tc.wait, Elevation(tc.lat, tc.lon, tc.time), 0.1)
+			assert.Equal(t, tc.isDay, IsDay(tc.lat, tc.lon, tc.time))
+		})
+	}
+}
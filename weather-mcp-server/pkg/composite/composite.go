@@ -0,0 +1,259 @@
+// Package composite combines several weather providers into one, either
+// failing over between them in priority order or merging their answers.
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/stats"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// Provider is the method set shared by the weather backends.
+type Provider interface {
+	Current(ctx context.Context, city string, opts ...weatherapi.Option) (*models.CurrentResponse, error)
+	Forecast(ctx context.Context, city string, days int, opts ...weatherapi.Option) (*models.ForecastResponse, error)
+	Alerts(ctx context.Context, city string) (*models.AlertsResponse, error)
+}
+
+type Mode int
+
+const (
+	// Failover asks the providers in priority order and returns the first answer.
+	Failover Mode = iota
+	// Consensus asks every provider for the current weather and merges the
+	// answers. Forecasts and alerts still fail over.
+	Consensus
+)
+
+type Composite struct {
+	mode      Mode
+	providers []Provider
+}
+
+// New returns a provider backed by providers, listed from the highest priority.
+func New(mode Mode, providers ...Provider) *Composite {
+	return &Composite{
+		mode:      mode,
+		providers: providers,
+	}
+}
+
+func (c *Composite) Current(ctx context.Context, city string, opts ...weatherapi.Option) (*models.CurrentResponse, error) {
+	if c.mode == Consensus {
+		return c.consensus(ctx, city, opts...)
+	}
+
+	return failover(ctx, c.providers, func(p Provider) (*models.CurrentResponse, error) {
+		return p.Current(ctx, city, opts...)
+	})
+}
+
+func (c *Composite) Forecast(ctx context.Context, city string, days int, opts ...weatherapi.Option) (*models.ForecastResponse, error) {
+	return failover(ctx, c.providers, func(p Provider) (*models.ForecastResponse, error) {
+		return p.Forecast(ctx, city, days, opts...)
+	})
+}
+
+func (c *Composite) Alerts(ctx context.Context, city string) (*models.AlertsResponse, error) {
+	return failover(ctx, c.providers, func(p Provider) (*models.AlertsResponse, error) {
+		return p.Alerts(ctx, city)
+	})
+}
+
+type historyProvider interface {
+	History(ctx context.Context, city string, date, endDate time.Time) (*models.HistoryResponse, error)
+}
+
+// History fails over between the providers that serve past weather.
+func (c *Composite) History(ctx context.Context, city string, date, endDate time.Time) (*models.HistoryResponse, error) {
+	return failover(ctx, c.providers, func(p Provider) (*models.HistoryResponse, error) {
+		history, ok := p.(historyProvider)
+		if !ok {
+			return nil, fmt.Errorf("weather history: %w", errors.ErrUnsupported)
+		}
+
+		return history.History(ctx, city, date, endDate)
+	})
+}
+
+type astronomyProvider interface {
+	Astronomy(ctx context.Context, city string, date time.Time) (*models.AstronomyResponse, error)
+}
+
+// Astronomy fails over between the providers that serve sun and moon times.
+func (c *Composite) Astronomy(ctx context.Context, city string, date time.Time) (*models.AstronomyResponse, error) {
+	return failover(ctx, c.providers, func(p Provider) (*models.AstronomyResponse, error) {
+		astronomy, ok := p.(astronomyProvider)
+		if !ok {
+			return nil, fmt.Errorf("astronomy: %w", errors.ErrUnsupported)
+		}
+
+		return astronomy.Astronomy(ctx, city, date)
+	})
+}
+
+type marineProvider interface {
+	Marine(ctx context.Context, query string, days int) (*models.MarineResponse, error)
+}
+
+// Marine fails over between the providers that serve marine forecasts.
+func (c *Composite) Marine(ctx context.Context, query string, days int) (*models.MarineResponse, error) {
+	return failover(ctx, c.providers, func(p Provider) (*models.MarineResponse, error) {
+		marine, ok := p.(marineProvider)
+		if !ok {
+			return nil, fmt.Errorf("marine forecast: %w", errors.ErrUnsupported)
+		}
+
+		return marine.Marine(ctx, query, days)
+	})
+}
+
+type searchProvider interface {
+	Search(ctx context.Context, query string) (models.SearchResponse, error)
+}
+
+// Search fails over between the providers that can search for places.
+func (c *Composite) Search(ctx context.Context, query string) (models.SearchResponse, error) {
+	result, err := failover(ctx, c.providers, func(p Provider) (*models.SearchResponse, error) {
+		search, ok := p.(searchProvider)
+		if !ok {
+			return nil, fmt.Errorf("location search: %w", errors.ErrUnsupported)
+		}
+
+		places, err := search.Search(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return &places, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return *result, nil
+}
+
+// failover calls the providers in order until one succeeds or fails with
+// an error that another provider would not fix.
+func failover[T any](ctx context.Context, providers []Provider, call func(Provider) (*T, error)) (*T, error) {
+	var errs []error
+
+	for _, provider := range providers {
+		result, err := call(provider)
+		if err == nil {
+			return result, nil
+		}
+
+		errs = append(errs, err)
+
+		if ctx.Err() != nil || !weatherapi.ProviderUnavailable(err) {
+			break
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// consensus queries every provider concurrently and merges the answers with
+// the median temperature and the majority condition. The rest of the data
+// comes from the highest-priority provider that answered.
+func (c *Composite) consensus(ctx context.Context, city string, opts ...weatherapi.Option) (*models.CurrentResponse, error) {
+	results := make([]*models.CurrentResponse, len(c.providers))
+	errs := make([]error, len(c.providers))
+
+	var wg sync.WaitGroup
+
+	for i, provider := range c.providers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i], errs[i] = provider.Current(ctx, city, opts...)
+		}()
+	}
+
+	wg.Wait()
+
+	var answers []*models.CurrentResponse
+
+	for _, result := range results {
+		if result != nil {
+			answers = append(answers, result)
+		}
+	}
+
+	if len(answers) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	merged := *answers[0]
+
+	temps := make([]float64, 0, len(answers))
+	tempsF := make([]float64, 0, len(answers))
+	feelsLike := make([]float64, 0, len(answers))
+	feelsLikeF := make([]float64, 0, len(answers))
+
+	for _, answer := range answers {
+		temps = append(temps, answer.Current.TempC)
+		tempsF = append(tempsF, answer.Current.TempF)
+		feelsLike = append(feelsLike, answer.Current.FeelslikeC)
+		feelsLikeF = append(feelsLikeF, answer.Current.FeelslikeF)
+	}
+
+	merged.Current.TempC = median(temps)
+	merged.Current.TempF = median(tempsF)
+	merged.Current.FeelslikeC = median(feelsLike)
+	merged.Current.FeelslikeF = median(feelsLikeF)
+
+	condition, votes := majority(answers)
+	merged.Current.Condition = condition
+
+	// temps is sorted by median.
+	merged.Current.Consensus = &models.Consensus{
+		Providers:      len(answers),
+		TempSpreadC:    math.Round((temps[len(temps)-1]-temps[0])*10) / 10,
+		ConditionVotes: votes,
+	}
+
+	return &merged, nil
+}
+
+// median sorts values in place and returns their median.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	return stats.Percentile(values, 50)
+}
+
+// majority returns the condition reported by most answers. Ties go to the
+// condition seen first, that is from the higher-priority provider.
+func majority(answers []*models.CurrentResponse) (models.Condition, int) {
+	counts := make(map[int64]int)
+
+	for _, answer := range answers {
+		counts[answer.Current.Condition.Code]++
+	}
+
+	var (
+		best  models.Condition
+		votes int
+	)
+
+	for _, answer := range answers {
+		if count := counts[answer.Current.Condition.Code]; count > votes {
+			best, votes = answer.Current.Condition, count
+		}
+	}
+
+	return best, votes
+}
@@ -0,0 +1,304 @@
+package composite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// backend is a provider whose answers come from an httptest server.
+type backend struct {
+	url    string
+	client *http.Client
+	calls  *atomic.Int64
+}
+
+type backendConfig struct {
+	status int
+	delay  time.Duration
+	tempC  float64
+	code   int64
+}
+
+func newBackend(t *testing.T, name string, cfg backendConfig) *backend {
+	t.Helper()
+
+	calls := new(atomic.Int64)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if cfg.delay > 0 {
+			time.Sleep(cfg.delay)
+		}
+
+		if cfg.status != http.StatusOK {
+			http.Error(w, "", cfg.status)
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.CurrentResponse{
+			Location: models.Location{Name: name},
+			Current: models.Current{
+				TempC:      cfg.tempC,
+				TempF:      cfg.tempC*9/5 + 32,
+				FeelslikeC: cfg.tempC - 1,
+				FeelslikeF: (cfg.tempC-1)*9/5 + 32,
+				Humidity:   50,
+				Condition:  weatherapi.NewCondition(cfg.code, true),
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := server.Client()
+	client.Timeout = 50 * time.Millisecond
+
+	return &backend{url: server.URL, client: client, calls: calls}
+}
+
+func (b *backend) Current(ctx context.Context, _ string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := b.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, &weatherapi.StatusError{API: "test", StatusCode: response.StatusCode}
+	}
+
+	var data models.CurrentResponse
+
+	if err := json.NewDecoder(response.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (b *backend) Forecast(_ context.Context, _ string, _ int, _ ...weatherapi.Option) (*models.ForecastResponse, error) {
+	return nil, fmt.Errorf("test: forecast: %w", errors.ErrUnsupported)
+}
+
+func (b *backend) Alerts(ctx context.Context, city string) (*models.AlertsResponse, error) {
+	current, err := b.Current(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AlertsResponse{Location: current.Location}, nil
+}
+
+func TestFailover(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		primary   backendConfig
+		secondary backendConfig
+		errString string
+		wait      string
+		calls     int64
+	}{
+		"primary_answers": {
+			primary:   backendConfig{status: http.StatusOK, tempC: 10},
+			secondary: backendConfig{status: http.StatusOK, tempC: 20},
+			wait:      "primary",
+			calls:     0,
+		},
+		"server_error": {
+			primary:   backendConfig{status: http.StatusBadGateway},
+			secondary: backendConfig{status: http.StatusOK, tempC: 20},
+			wait:      "secondary",
+			calls:     1,
+		},
+		"timeout": {
+			primary:   backendConfig{status: http.StatusOK, delay: 200 * time.Millisecond},
+			secondary: backendConfig{status: http.StatusOK, tempC: 20},
+			wait:      "secondary",
+			calls:     1,
+		},
+		"client_error_is_not_retried": {
+			primary:   backendConfig{status: http.StatusBadRequest},
+			secondary: backendConfig{status: http.StatusOK, tempC: 20},
+			errString: "test API not available. Code: 400",
+			calls:     0,
+		},
+		"all_providers_fail": {
+			primary:   backendConfig{status: http.StatusInternalServerError},
+			secondary: backendConfig{status: http.StatusServiceUnavailable},
+			errString: "test API not available. Code: 500\ntest API not available. Code: 503",
+			calls:     1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			secondary := newBackend(t, "secondary", tc.secondary)
+			provider := New(Failover, newBackend(t, "primary", tc.primary), secondary)
+
+			result, err := provider.Current(context.Background(), "London")
+			if tc.errString != "" {
+				assert.EqualError(t, err, tc.errString)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wait, result.Location.Name)
+				assert.Nil(t, result.Current.Consensus)
+			}
+
+			assert.Equal(t, tc.calls, secondary.calls.Load())
+		})
+	}
+}
+
+func TestFailoverUnsupported(t *testing.T) {
+	t.Parallel()
+
+	provider := New(Failover,
+		newBackend(t, "primary", backendConfig{status: http.StatusOK}),
+		newBackend(t, "secondary", backendConfig{status: http.StatusOK}),
+	)
+
+	_, err := provider.Forecast(context.Background(), "London", 3)
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+
+	_, err = provider.History(context.Background(), "London", time.Now(), time.Now())
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+
+	_, err = provider.Astronomy(context.Background(), "London", time.Now())
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+
+	_, err = provider.Marine(context.Background(), "Brighton", 1)
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+// outsideCoverage is a provider that does not cover the location, as NWS
+// answers for places outside the United States.
+type outsideCoverage struct {
+	*backend
+}
+
+func (outsideCoverage) Current(_ context.Context, _ string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+	return nil, fmt.Errorf("nws: 48.8534,2.3488 is outside the NWS coverage: %w", errors.ErrUnsupported)
+}
+
+func TestFailoverOutsideCoverage(t *testing.T) {
+	t.Parallel()
+
+	secondary := newBackend(t, "secondary", backendConfig{status: http.StatusOK, tempC: 16, code: 1000})
+
+	provider := New(Failover,
+		outsideCoverage{newBackend(t, "nws", backendConfig{status: http.StatusOK})},
+		secondary,
+	)
+
+	result, err := provider.Current(context.Background(), "Paris")
+	require.NoError(t, err)
+
+	assert.Equal(t, "secondary", result.Location.Name)
+	assert.Equal(t, int64(1), secondary.calls.Load())
+}
+
+func TestConsensus(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		backends  []backendConfig
+		errString string
+		wait      *models.CurrentResponse
+	}{
+		"majority_and_median": {
+			backends: []backendConfig{
+				{status: http.StatusOK, tempC: 20, code: 1000},
+				{status: http.StatusOK, tempC: 10, code: 1003},
+				{status: http.StatusOK, tempC: 12, code: 1003},
+			},
+			wait: &models.CurrentResponse{
+				Location: models.Location{Name: "backend-0"},
+				Current: models.Current{
+					TempC:      12,
+					TempF:      53.6,
+					FeelslikeC: 11,
+					FeelslikeF: 51.8,
+					Humidity:   50,
+					Condition:  weatherapi.NewCondition(1003, true),
+					Consensus: &models.Consensus{
+						Providers:      3,
+						TempSpreadC:    10,
+						ConditionVotes: 2,
+					},
+				},
+			},
+		},
+		"tie_goes_to_priority": {
+			backends: []backendConfig{
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusOK, tempC: 10, code: 1183},
+				{status: http.StatusOK, tempC: 11, code: 1009},
+			},
+			wait: &models.CurrentResponse{
+				Location: models.Location{Name: "backend-1"},
+				Current: models.Current{
+					TempC:      10.5,
+					TempF:      50.9,
+					FeelslikeC: 9.5,
+					FeelslikeF: 49.1,
+					Humidity:   50,
+					Condition:  weatherapi.NewCondition(1183, true),
+					Consensus: &models.Consensus{
+						Providers:      2,
+						TempSpreadC:    1,
+						ConditionVotes: 1,
+					},
+				},
+			},
+		},
+		"all_providers_fail": {
+			backends: []backendConfig{
+				{status: http.StatusInternalServerError},
+				{status: http.StatusBadRequest},
+			},
+			errString: "test API not available. Code: 500\ntest API not available. Code: 400",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			providers := make([]Provider, 0, len(tc.backends))
+			for i, cfg := range tc.backends {
+				providers = append(providers, newBackend(t, fmt.Sprintf("backend-%d", i), cfg))
+			}
+
+			result, err := New(Consensus, providers...).Current(context.Background(), "London")
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			if tc.wait != nil {
+				require.NotNil(t, result)
+				assert.InDelta(t, tc.wait.Current.TempF, result.Current.TempF, 1e-9)
+				assert.InDelta(t, tc.wait.Current.FeelslikeF, result.Current.FeelslikeF, 1e-9)
+				result.Current.TempF, result.Current.FeelslikeF = tc.wait.Current.TempF, tc.wait.Current.FeelslikeF
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
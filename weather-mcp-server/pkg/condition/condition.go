@@ -0,0 +1,154 @@
+// Package condition classifies WeatherAPI condition codes, so that the
+// weather can be judged without reading condition text in any language.
+package condition
+
+type Kind int
+
+const (
+	Unknown Kind = iota
+	Clear
+	PartlyCloudy
+	Cloudy
+	Overcast
+	Fog
+	Drizzle
+	Rain
+	Sleet
+	Snow
+	IcePellets
+	Thunder
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Clear:
+		return "clear"
+	case PartlyCloudy:
+		return "partly cloudy"
+	case Cloudy:
+		return "cloudy"
+	case Overcast:
+		return "overcast"
+	case Fog:
+		return "fog"
+	case Drizzle:
+		return "drizzle"
+	case Rain:
+		return "rain"
+	case Sleet:
+		return "sleet"
+	case Snow:
+		return "snow"
+	case IcePellets:
+		return "ice pellets"
+	case Thunder:
+		return "thunder"
+	default:
+		return "unknown"
+	}
+}
+
+// Intensity is how strong the precipitation or fog is. Dry weather has none.
+type Intensity int
+
+const (
+	None Intensity = iota
+	Light
+	Moderate
+	Heavy
+)
+
+func (i Intensity) String() string {
+	switch i {
+	case Light:
+		return "light"
+	case Moderate:
+		return "moderate"
+	case Heavy:
+		return "heavy"
+	default:
+		return "none"
+	}
+}
+
+// Class is what a condition code says about the weather.
+type Class struct {
+	Kind      Kind
+	Intensity Intensity
+	// Freezing is set for fog, drizzle and rain that freeze on contact.
+	Freezing bool
+}
+
+// classes maps the WeatherAPI condition codes to their class. Patchy and
+// possible precipitation counts as light, and "moderate or heavy" as heavy.
+var classes = map[int64]Class{
+	1000: {Kind: Clear},
+	1003: {Kind: PartlyCloudy},
+	1006: {Kind: Cloudy},
+	1009: {Kind: Overcast},
+	1030: {Kind: Fog, Intensity: Light},
+	1063: {Kind: Rain, Intensity: Light},
+	1066: {Kind: Snow, Intensity: Light},
+	1069: {Kind: Sleet, Intensity: Light},
+	1072: {Kind: Drizzle, Intensity: Light, Freezing: true},
+	1087: {Kind: Thunder, Intensity: Light},
+	1114: {Kind: Snow, Intensity: Moderate},
+	1117: {Kind: Snow, Intensity: Heavy},
+	1135: {Kind: Fog, Intensity: Moderate},
+	1147: {Kind: Fog, Intensity: Moderate, Freezing: true},
+	1150: {Kind: Drizzle, Intensity: Light},
+	1153: {Kind: Drizzle, Intensity: Light},
+	1168: {Kind: Drizzle, Intensity: Moderate, Freezing: true},
+	1171: {Kind: Drizzle, Intensity: Heavy, Freezing: true},
+	1180: {Kind: Rain, Intensity: Light},
+	1183: {Kind: Rain, Intensity: Light},
+	1186: {Kind: Rain, Intensity: Moderate},
+	1189: {Kind: Rain, Intensity: Moderate},
+	1192: {Kind: Rain, Intensity: Heavy},
+	1195: {Kind: Rain, Intensity: Heavy},
+	1198: {Kind: Rain, Intensity: Light, Freezing: true},
+	1201: {Kind: Rain, Intensity: Heavy, Freezing: true},
+	1204: {Kind: Sleet, Intensity: Light},
+	1207: {Kind: Sleet, Intensity: Heavy},
+	1210: {Kind: Snow, Intensity: Light},
+	1213: {Kind: Snow, Intensity: Light},
+	1216: {Kind: Snow, Intensity: Moderate},
+	1219: {Kind: Snow, Intensity: Moderate},
+	1222: {Kind: Snow, Intensity: Heavy},
+	1225: {Kind: Snow, Intensity: Heavy},
+	1237: {Kind: IcePellets, Intensity: Moderate},
+	1240: {Kind: Rain, Intensity: Light},
+	1243: {Kind: Rain, Intensity: Heavy},
+	1246: {Kind: Rain, Intensity: Heavy},
+	1249: {Kind: Sleet, Intensity: Light},
+	1252: {Kind: Sleet, Intensity: Heavy},
+	1255: {Kind: Snow, Intensity: Light},
+	1258: {Kind: Snow, Intensity: Heavy},
+	1261: {Kind: IcePellets, Intensity: Light},
+	1264: {Kind: IcePellets, Intensity: Heavy},
+	1273: {Kind: Thunder, Intensity: Light},
+	1276: {Kind: Thunder, Intensity: Heavy},
+	1279: {Kind: Thunder, Intensity: Light},
+	1282: {Kind: Thunder, Intensity: Heavy},
+}
+
+// Classify returns the class of a WeatherAPI condition code. Unknown codes,
+// including the zero code of a condition given only as text, are Unknown.
+func Classify(code int64) Class {
+	return classes[code]
+}
+
+// Rainy reports whether liquid precipitation falls, thunderstorms included.
+func (c Class) Rainy() bool {
+	return c.Kind == Drizzle || c.Kind == Rain || c.Kind == Thunder
+}
+
+// Wintry reports whether snow, sleet or ice falls.
+func (c Class) Wintry() bool {
+	return c.Kind == Sleet || c.Kind == Snow || c.Kind == IcePellets
+}
+
+// Clouded reports whether clouds cover some or all of the sky.
+func (c Class) Clouded() bool {
+	return c.Kind == PartlyCloudy || c.Kind == Cloudy || c.Kind == Overcast
+}
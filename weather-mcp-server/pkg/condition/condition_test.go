@@ -0,0 +1,69 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		code int64
+		wait Class
+	}{
+		"sunny":                {code: 1000, wait: Class{Kind: Clear}},
+		"patchy_light_drizzle": {code: 1150, wait: Class{Kind: Drizzle, Intensity: Light}},
+		"blizzard":             {code: 1117, wait: Class{Kind: Snow, Intensity: Heavy}},
+		"freezing_rain":        {code: 1201, wait: Class{Kind: Rain, Intensity: Heavy, Freezing: true}},
+		"rain_with_thunder":    {code: 1276, wait: Class{Kind: Thunder, Intensity: Heavy}},
+		"no_code":              {code: 0, wait: Class{}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, Classify(tc.code))
+		})
+	}
+}
+
+func TestEveryConditionIsClassified(t *testing.T) {
+	t.Parallel()
+
+	for code := int64(1000); code < 1300; code++ {
+		known := weatherapi.NewCondition(code, true).Text != ""
+
+		assert.Equal(t, known, Classify(code).Kind != Unknown, "code %d", code)
+	}
+}
+
+func TestClassGroups(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		code                   int64
+		rainy, wintry, clouded bool
+	}{
+		"clear":         {code: 1000},
+		"partly_cloudy": {code: 1003, clouded: true},
+		"overcast":      {code: 1009, clouded: true},
+		"drizzle":       {code: 1153, rainy: true},
+		"thunder":       {code: 1087, rainy: true},
+		"sleet":         {code: 1204, wintry: true},
+		"ice_pellets":   {code: 1237, wintry: true},
+		"fog":           {code: 1135},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			class := Classify(tc.code)
+
+			assert.Equal(t, tc.rainy, class.Rainy())
+			assert.Equal(t, tc.wintry, class.Wintry())
+			assert.Equal(t, tc.clouded, class.Clouded())
+		})
+	}
+}
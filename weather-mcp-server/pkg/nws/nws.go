@@ -0,0 +1,403 @@
+// Package nws is a keyless weather provider for the United States backed by
+// the National Weather Service API (api.weather.gov) that maps its responses
+// into the WeatherAPI models.
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+const (
+	baseURL   = "https://api.weather.gov"
+	userAgent = "weather-mcp-server (github.com/TuanKiri/weather-mcp-server)"
+	country   = "United States of America"
+)
+
+// Geocoder resolves a city name or a "lat,lon" query to a location,
+// because the NWS API only accepts coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, city string) (*models.Location, error)
+}
+
+type NWS struct {
+	baseURL  string
+	client   *http.Client
+	geocoder Geocoder
+}
+
+func New(timeout time.Duration, geocoder Geocoder) *NWS {
+	return &NWS{
+		baseURL: baseURL,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		geocoder: geocoder,
+	}
+}
+
+type pointResponse struct {
+	Properties struct {
+		GridID           string `json:"gridId"`
+		GridX            int64  `json:"gridX"`
+		GridY            int64  `json:"gridY"`
+		TimeZone         string `json:"timeZone"`
+		RelativeLocation struct {
+			Properties struct {
+				City  string `json:"city"`
+				State string `json:"state"`
+			} `json:"properties"`
+		} `json:"relativeLocation"`
+	} `json:"properties"`
+}
+
+type stationsResponse struct {
+	Features []struct {
+		Properties struct {
+			StationIdentifier string `json:"stationIdentifier"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+type quantity struct {
+	Value *float64 `json:"value"`
+}
+
+func (q quantity) or(fallback float64) float64 {
+	if q.Value == nil {
+		return fallback
+	}
+
+	return *q.Value
+}
+
+type observationResponse struct {
+	Properties struct {
+		Timestamp          time.Time `json:"timestamp"`
+		TextDescription    string    `json:"textDescription"`
+		Icon               string    `json:"icon"`
+		Temperature        quantity  `json:"temperature"`
+		WindDirection      quantity  `json:"windDirection"`
+		WindSpeed          quantity  `json:"windSpeed"`
+		WindGust           quantity  `json:"windGust"`
+		BarometricPressure quantity  `json:"barometricPressure"`
+		Visibility         quantity  `json:"visibility"`
+		RelativeHumidity   quantity  `json:"relativeHumidity"`
+		WindChill          quantity  `json:"windChill"`
+		HeatIndex          quantity  `json:"heatIndex"`
+	} `json:"properties"`
+}
+
+// Current returns the latest observation from the station nearest to the
+// city. Options only apply to WeatherAPI and are ignored.
+func (n *NWS) Current(ctx context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+	location, err := n.locate(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	var point pointResponse
+
+	if err := n.get(ctx, fmt.Sprintf("/points/%.4f,%.4f", location.Lat, location.Lon), nil, &point); err != nil {
+		// The NWS answers 404 for points outside the United States, which
+		// is a place it does not cover rather than one that does not exist.
+		var statusErr *weatherapi.StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("nws: %.4f,%.4f is outside the NWS coverage: %w", location.Lat, location.Lon, errors.ErrUnsupported)
+		}
+
+		return nil, err
+	}
+
+	grid := point.Properties
+
+	var stations stationsResponse
+
+	if err := n.get(ctx, fmt.Sprintf("/gridpoints/%s/%d,%d/stations", grid.GridID, grid.GridX, grid.GridY),
+		url.Values{"limit": {"1"}}, &stations); err != nil {
+		return nil, err
+	}
+
+	if len(stations.Features) == 0 {
+		return nil, fmt.Errorf("no observation stations near %q", city)
+	}
+
+	station := stations.Features[0].Properties.StationIdentifier
+
+	var observation observationResponse
+
+	if err := n.get(ctx, "/stations/"+station+"/observations/latest", nil, &observation); err != nil {
+		return nil, err
+	}
+
+	if relative := grid.RelativeLocation.Properties; relative.City != "" {
+		location.Name, location.Region = relative.City, relative.State
+	}
+
+	location.Country = country
+
+	if location.TzID == "" {
+		location.TzID = grid.TimeZone
+	}
+
+	obs := observation.Properties
+
+	// A station without a temperature has nothing worth reporting, so the
+	// next provider is asked instead.
+	if obs.Temperature.Value == nil {
+		return nil, fmt.Errorf("nws: station %s reported no temperature: %w", station, weatherapi.ErrUnavailable)
+	}
+
+	tempC := *obs.Temperature.Value
+	feelsLikeC := obs.HeatIndex.or(obs.WindChill.or(tempC))
+
+	cond := condition(obs.Icon)
+	if obs.TextDescription != "" {
+		cond.Text = obs.TextDescription
+	}
+
+	data := &models.CurrentResponse{
+		Location: *location,
+		Current: models.Current{
+			TempC:      round(tempC),
+			TempF:      round(units.CelsiusToFahrenheit(tempC)),
+			FeelslikeC: round(feelsLikeC),
+			FeelslikeF: round(units.CelsiusToFahrenheit(feelsLikeC)),
+			Condition:  cond,
+		},
+	}
+
+	current := &data.Current
+
+	// Stations often leave out a reading. Those are listed as missing
+	// rather than reported as zero.
+	measure := func(q quantity, field string, set func(float64)) {
+		if q.Value == nil {
+			current.Missing = append(current.Missing, field)
+			return
+		}
+
+		set(*q.Value)
+	}
+
+	measure(obs.WindSpeed, models.FieldWindKph, func(kph float64) {
+		current.WindKph, current.WindMph = round(kph), round(units.KPHToMPH(kph))
+	})
+	measure(obs.WindDirection, models.FieldWindDir, func(degrees float64) {
+		current.WindDir = weatherapi.WindDirection(degrees)
+	})
+	measure(obs.WindGust, models.FieldGustKph, func(kph float64) {
+		current.GustKph = round(kph)
+	})
+	measure(obs.RelativeHumidity, models.FieldHumidity, func(percent float64) {
+		current.Humidity = int64(math.Round(percent))
+	})
+	measure(obs.BarometricPressure, models.FieldPressureMb, func(pa float64) {
+		current.PressureMb = round(pa / 100)
+	})
+	measure(obs.Visibility, models.FieldVisibility, func(metres float64) {
+		current.Visibility = round(metres / 1000)
+	})
+
+	if !obs.Timestamp.IsZero() {
+		data.Current.LastUpdatedEpoch = obs.Timestamp.Unix()
+		data.Current.LastUpdated = obs.Timestamp.In(zone(location.TzID)).Format("2006-01-02 15:04")
+	}
+
+	return data, nil
+}
+
+// Forecast is not supported because the NWS publishes 12-hour periods
+// rather than daily aggregates.
+func (n *NWS) Forecast(_ context.Context, _ string, _ int, _ ...weatherapi.Option) (*models.ForecastResponse, error) {
+	return nil, fmt.Errorf("nws: daily forecast: %w", errors.ErrUnsupported)
+}
+
+type alertsResponse struct {
+	Features []struct {
+		Properties struct {
+			Headline    string `json:"headline"`
+			MessageType string `json:"messageType"`
+			Severity    string `json:"severity"`
+			Urgency     string `json:"urgency"`
+			AreaDesc    string `json:"areaDesc"`
+			Category    string `json:"category"`
+			Certainty   string `json:"certainty"`
+			Event       string `json:"event"`
+			Effective   string `json:"effective"`
+			Expires     string `json:"expires"`
+			Description string `json:"description"`
+			Instruction string `json:"instruction"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// Alerts returns the active alerts for the city's coordinates.
+func (n *NWS) Alerts(ctx context.Context, city string) (*models.AlertsResponse, error) {
+	location, err := n.locate(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	location.Country = country
+
+	var data alertsResponse
+
+	if err := n.get(ctx, "/alerts/active",
+		url.Values{"point": {fmt.Sprintf("%.4f,%.4f", location.Lat, location.Lon)}}, &data); err != nil {
+		return nil, err
+	}
+
+	response := &models.AlertsResponse{
+		Location: *location,
+	}
+
+	for _, feature := range data.Features {
+		alert := feature.Properties
+
+		response.Alerts.Alert = append(response.Alerts.Alert, models.Alert{
+			Headline:    alert.Headline,
+			MsgType:     alert.MessageType,
+			Severity:    alert.Severity,
+			Urgency:     alert.Urgency,
+			Areas:       alert.AreaDesc,
+			Category:    alert.Category,
+			Certainty:   alert.Certainty,
+			Event:       alert.Event,
+			Effective:   alert.Effective,
+			Expires:     alert.Expires,
+			Desc:        alert.Description,
+			Instruction: alert.Instruction,
+		})
+	}
+
+	return response, nil
+}
+
+func (n *NWS) locate(ctx context.Context, city string) (*models.Location, error) {
+	if n.geocoder != nil {
+		return n.geocoder.Geocode(ctx, city)
+	}
+
+	lat, lon, ok := weatherapi.ParseCoordinates(city)
+	if !ok {
+		return nil, fmt.Errorf("nws: %q is not a lat,lon query and no geocoder is configured", city)
+	}
+
+	return &models.Location{Name: city, Lat: lat, Lon: lon}, nil
+}
+
+func (n *NWS) get(ctx context.Context, path string, query url.Values, data any) error {
+	endpoint := n.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	// The NWS API rejects requests without a User-Agent identifying the application.
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set("Accept", "application/geo+json")
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return &weatherapi.StatusError{API: "NWS", StatusCode: response.StatusCode}
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, data)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// zone returns the named time zone, or UTC if it is unknown.
+func zone(name string) *time.Location {
+	if location, err := time.LoadLocation(name); err == nil && name != "" {
+		return location
+	}
+
+	return time.UTC
+}
+
+// iconCodes maps NWS icon names to WeatherAPI condition codes.
+var iconCodes = map[string]int64{
+	"skc":             1000,
+	"few":             1003,
+	"sct":             1003,
+	"bkn":             1006,
+	"ovc":             1009,
+	"wind_skc":        1000,
+	"wind_few":        1003,
+	"wind_sct":        1003,
+	"wind_bkn":        1006,
+	"wind_ovc":        1009,
+	"snow":            1219,
+	"rain_snow":       1207,
+	"rain_sleet":      1207,
+	"snow_sleet":      1207,
+	"sleet":           1207,
+	"fzra":            1201,
+	"rain_fzra":       1201,
+	"snow_fzra":       1201,
+	"rain":            1189,
+	"rain_showers":    1243,
+	"rain_showers_hi": 1240,
+	"tsra":            1276,
+	"tsra_sct":        1273,
+	"tsra_hi":         1273,
+	"tornado":         1276,
+	"hurricane":       1246,
+	"tropical_storm":  1246,
+	"dust":            1030,
+	"smoke":           1030,
+	"haze":            1030,
+	"hot":             1000,
+	"cold":            1000,
+	"blizzard":        1117,
+	"fog":             1135,
+}
+
+// condition derives the WeatherAPI condition from an NWS icon URL such as
+// https://api.weather.gov/icons/land/night/rain,40?size=medium.
+func condition(icon string) models.Condition {
+	u, err := url.Parse(icon)
+	if err != nil {
+		return models.Condition{}
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return models.Condition{}
+	}
+
+	period := segments[len(segments)-2]
+	name, _, _ := strings.Cut(segments[len(segments)-1], ",")
+
+	return weatherapi.NewCondition(iconCodes[name], period != "night")
+}
@@ -0,0 +1,305 @@
+package nws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+type stubGeocoder struct{}
+
+func (stubGeocoder) Geocode(_ context.Context, city string) (*models.Location, error) {
+	if city != "New York" {
+		return nil, errors.New("location not found")
+	}
+
+	return &models.Location{Name: "New York", Region: "New York", Country: "United States", Lat: 40.71427, Lon: -74.00597}, nil
+}
+
+// newTestServer serves the NWS fixtures from the mock directory.
+func newTestServer(t *testing.T, geocoder Geocoder) *NWS {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			http.Error(w, "", http.StatusForbidden)
+			return
+		}
+
+		var fixture string
+
+		switch {
+		case r.URL.Path == "/points/40.7143,-74.0060":
+			fixture = "points.json"
+		case r.URL.Path == "/gridpoints/OKX/33,35/stations":
+			fixture = "stations.json"
+		case r.URL.Path == "/stations/KNYC/observations/latest":
+			fixture = "observation.json"
+		case r.URL.Path == "/alerts/active" && r.URL.Query().Get("point") == "40.7143,-74.0060":
+			fixture = "alerts.json"
+		default:
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("mock", fixture))
+		if err != nil {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return &NWS{
+		baseURL:  server.URL,
+		client:   server.Client(),
+		geocoder: geocoder,
+	}
+}
+
+func TestCurrentWeather(t *testing.T) {
+	t.Parallel()
+
+	current := models.Current{
+		TempC:      12.2,
+		TempF:      54,
+		WindKph:    14.8,
+		WindMph:    9.2,
+		WindDir:    "SW",
+		Humidity:   61,
+		FeelslikeC: 10.4,
+		FeelslikeF: 50.7,
+		Visibility: 16.1,
+		PressureMb: 1015.2,
+		Condition: models.Condition{
+			Text: "Mostly Cloudy",
+			Icon: "//cdn.weatherapi.com/weather/64x64/day/119.png",
+			Code: 1006,
+		},
+		LastUpdatedEpoch: 1744383060,
+		LastUpdated:      "2025-04-11 10:51",
+		Missing:          []string{models.FieldGustKph},
+	}
+
+	testCases := map[string]struct {
+		geocoder  Geocoder
+		city      string
+		errString string
+		wait      *models.CurrentResponse
+	}{
+		"geocoded_city": {
+			geocoder: stubGeocoder{},
+			city:     "New York",
+			wait: &models.CurrentResponse{
+				Location: models.Location{
+					Name:    "Hoboken",
+					Region:  "NJ",
+					Country: "United States of America",
+					Lat:     40.71427,
+					Lon:     -74.00597,
+					TzID:    "America/New_York",
+				},
+				Current: current,
+			},
+		},
+		"coordinates_without_geocoder": {
+			city: "40.7143,-74.006",
+			wait: &models.CurrentResponse{
+				Location: models.Location{
+					Name:    "Hoboken",
+					Region:  "NJ",
+					Country: "United States of America",
+					Lat:     40.7143,
+					Lon:     -74.006,
+					TzID:    "America/New_York",
+				},
+				Current: current,
+			},
+		},
+		"city_without_geocoder": {
+			city:      "New York",
+			errString: `nws: "New York" is not a lat,lon query and no geocoder is configured`,
+		},
+		"outside_coverage": {
+			city:      "51.5,-0.12",
+			errString: "nws: 51.5000,-0.1200 is outside the NWS coverage: unsupported operation",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := newTestServer(t, tc.geocoder).Current(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestCurrentWeatherOutsideCoverage(t *testing.T) {
+	t.Parallel()
+
+	_, err := newTestServer(t, nil).Current(context.Background(), "48.8534,2.3488")
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+	assert.False(t, errors.Is(err, weatherapi.ErrLocationNotFound))
+}
+
+// newObservationServer serves the point and station fixtures with the
+// given latest observation.
+func newObservationServer(t *testing.T, observation string) *NWS {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+
+		switch r.URL.Path {
+		case "/points/40.7143,-74.0060":
+			fixture = "points.json"
+		case "/gridpoints/OKX/33,35/stations":
+			fixture = "stations.json"
+		case "/stations/KNYC/observations/latest":
+			w.Write([]byte(observation))
+			return
+		default:
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("mock", fixture))
+		if err != nil {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return &NWS{baseURL: server.URL, client: server.Client()}
+}
+
+func TestCurrentWeatherNullReadings(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		observation string
+		errString   string
+		wait        models.Current
+	}{
+		"no_temperature": {
+			observation: `{"properties": {"temperature": {"value": null}, "relativeHumidity": {"value": 61}}}`,
+			errString:   "nws: station KNYC reported no temperature: weather API is temporarily unavailable",
+		},
+		"only_temperature": {
+			observation: `{"properties": {"temperature": {"value": 12.2}, "icon": "https://api.weather.gov/icons/land/day/bkn?size=medium"}}`,
+			wait: models.Current{
+				TempC:      12.2,
+				TempF:      54,
+				FeelslikeC: 12.2,
+				FeelslikeF: 54,
+				Condition: models.Condition{
+					Text: "Cloudy",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/119.png",
+					Code: 1006,
+				},
+				Missing: []string{
+					models.FieldWindKph, models.FieldWindDir, models.FieldGustKph,
+					models.FieldHumidity, models.FieldPressureMb, models.FieldVisibility,
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := newObservationServer(t, tc.observation).Current(context.Background(), "40.7143,-74.006")
+			if tc.errString != "" {
+				assert.EqualError(t, err, tc.errString)
+				assert.ErrorIs(t, err, weatherapi.ErrUnavailable)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wait, result.Current)
+		})
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	result, err := newTestServer(t, stubGeocoder{}).Alerts(context.Background(), "New York")
+	require.NoError(t, err)
+
+	assert.Equal(t, "United States of America", result.Location.Country)
+	assert.Equal(t, []models.Alert{
+		{
+			Headline:  "Wind Advisory issued April 11 at 10:00AM EDT until April 11 at 8:00PM EDT by NWS Upton NY",
+			MsgType:   "Alert",
+			Severity:  "Moderate",
+			Urgency:   "Expected",
+			Areas:     "New York (Manhattan); Bronx",
+			Category:  "Met",
+			Certainty: "Likely",
+			Event:     "Wind Advisory",
+			Effective: "2025-04-11T10:00:00-04:00",
+			Expires:   "2025-04-11T20:00:00-04:00",
+			Desc:      "West winds 20 to 30 mph with gusts up to 50 mph expected.",
+		},
+	}, result.Alerts.Alert)
+}
+
+func TestForecast(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0, nil).Forecast(context.Background(), "New York", 3)
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
+
+func TestCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		icon string
+		wait models.Condition
+	}{
+		"night_rain_with_probability": {
+			icon: "https://api.weather.gov/icons/land/night/rain,40?size=medium",
+			wait: models.Condition{
+				Text: "Moderate rain",
+				Icon: "//cdn.weatherapi.com/weather/64x64/night/302.png",
+				Code: 1189,
+			},
+		},
+		"unknown_icon": {
+			icon: "https://api.weather.gov/icons/land/day/unknown",
+			wait: models.Condition{},
+		},
+		"empty": {
+			wait: models.Condition{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, condition(tc.icon))
+		})
+	}
+}
@@ -0,0 +1,398 @@
+// Package openmeteo is a keyless weather provider backed by Open-Meteo
+// that maps its responses into the WeatherAPI models.
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/units"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+const (
+	forecastURL  = "https://api.open-meteo.com"
+	geocodingURL = "https://geocoding-api.open-meteo.com"
+
+	maxForecastDays = 16
+)
+
+const (
+	currentVariables = "temperature_2m,relative_humidity_2m,apparent_temperature,is_day,weather_code," +
+		"wind_speed_10m,wind_direction_10m,wind_gusts_10m,pressure_msl,visibility,uv_index"
+	dailyVariables = "weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum,snowfall_sum," +
+		"precipitation_probability_max,wind_speed_10m_max,uv_index_max,sunrise,sunset"
+)
+
+type OpenMeteo struct {
+	forecastURL  string
+	geocodingURL string
+	client       *http.Client
+}
+
+func New(timeout time.Duration) *OpenMeteo {
+	return &OpenMeteo{
+		forecastURL:  forecastURL,
+		geocodingURL: geocodingURL,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+type place struct {
+	Name        string  `json:"name"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"country_code"`
+	Admin1      string  `json:"admin1"`
+	Timezone    string  `json:"timezone"`
+}
+
+type geocodingResponse struct {
+	Results []place `json:"results"`
+}
+
+// Geocode resolves a city name or a "lat,lon" query to a location. Place
+// ids from a WeatherAPI search, airport codes and IP lookups are not
+// known to Open-Meteo.
+func (o *OpenMeteo) Geocode(ctx context.Context, city string) (*models.Location, error) {
+	if lat, lon, ok := weatherapi.ParseCoordinates(city); ok {
+		return &models.Location{Name: city, Lat: lat, Lon: lon}, nil
+	}
+
+	if weatherapi.IsPrefixedQuery(city) {
+		return nil, fmt.Errorf("query %q: %w", city, errors.ErrUnsupported)
+	}
+
+	// The search only knows names, so a qualifier such as the "ME" of
+	// "Portland, ME" picks among the places it returns.
+	name, qualifiers := qualifiers(city)
+
+	query := url.Values{
+		"name":  {name},
+		"count": {strconv.Itoa(geocodingCount)},
+	}
+
+	var data geocodingResponse
+
+	if err := o.get(ctx, o.geocodingURL+"/v1/search", query, &data); err != nil {
+		return nil, err
+	}
+
+	for _, place := range data.Results {
+		if !place.matches(qualifiers) {
+			continue
+		}
+
+		return &models.Location{
+			Name:    place.Name,
+			Region:  place.Admin1,
+			Country: place.Country,
+			Lat:     place.Latitude,
+			Lon:     place.Longitude,
+			TzID:    place.Timezone,
+		}, nil
+	}
+
+	return nil, &weatherapi.LocationError{Query: city}
+}
+
+type currentResponse struct {
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int64  `json:"utc_offset_seconds"`
+	Current          struct {
+		Time                string  `json:"time"`
+		Temperature         float64 `json:"temperature_2m"`
+		RelativeHumidity    float64 `json:"relative_humidity_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		IsDay               int64   `json:"is_day"`
+		WeatherCode         int64   `json:"weather_code"`
+		WindSpeed           float64 `json:"wind_speed_10m"`
+		WindDirection       float64 `json:"wind_direction_10m"`
+		WindGusts           float64 `json:"wind_gusts_10m"`
+		PressureMSL         float64 `json:"pressure_msl"`
+		Visibility          float64 `json:"visibility"`
+		UVIndex             float64 `json:"uv_index"`
+	} `json:"current"`
+}
+
+// Current returns the current weather. Options only apply to WeatherAPI and are ignored.
+func (o *OpenMeteo) Current(ctx context.Context, city string, _ ...weatherapi.Option) (*models.CurrentResponse, error) {
+	location, err := o.Geocode(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	query := coordinates(location)
+	query.Set("current", currentVariables)
+	query.Set("timezone", "auto")
+
+	var data currentResponse
+
+	if err := o.get(ctx, o.forecastURL+"/v1/forecast", query, &data); err != nil {
+		return nil, err
+	}
+
+	if location.TzID == "" {
+		location.TzID = data.Timezone
+	}
+
+	current := data.Current
+
+	return &models.CurrentResponse{
+		Location: *location,
+		Current: models.Current{
+			LastUpdatedEpoch: observedAt(current.Time, data.UTCOffsetSeconds),
+			LastUpdated:      strings.Replace(current.Time, "T", " ", 1),
+			TempC:            current.Temperature,
+			TempF:            fahrenheit(current.Temperature),
+			WindKph:          current.WindSpeed,
+			WindMph:          mph(current.WindSpeed),
+			WindDir:          weatherapi.WindDirection(current.WindDirection),
+			Humidity:         int64(math.Round(current.RelativeHumidity)),
+			FeelslikeC:       current.ApparentTemperature,
+			FeelslikeF:       fahrenheit(current.ApparentTemperature),
+			Visibility:       current.Visibility / 1000,
+			UV:               current.UVIndex,
+			GustKph:          current.WindGusts,
+			PressureMb:       current.PressureMSL,
+			Condition:        weatherapi.NewCondition(conditionCode(current.WeatherCode), current.IsDay == 1),
+		},
+	}, nil
+}
+
+type forecastResponse struct {
+	Daily struct {
+		Time                        []string  `json:"time"`
+		WeatherCode                 []int64   `json:"weather_code"`
+		TemperatureMax              []float64 `json:"temperature_2m_max"`
+		TemperatureMin              []float64 `json:"temperature_2m_min"`
+		PrecipitationSum            []float64 `json:"precipitation_sum"`
+		SnowfallSum                 []float64 `json:"snowfall_sum"`
+		PrecipitationProbabilityMax []int64   `json:"precipitation_probability_max"`
+		WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
+		UVIndexMax                  []float64 `json:"uv_index_max"`
+		Sunrise                     []string  `json:"sunrise"`
+		Sunset                      []string  `json:"sunset"`
+	} `json:"daily"`
+}
+
+// Forecast returns up to 16 days of daily forecast. The average temperature
+// is the midpoint of the daily extremes and hourly data is not included.
+func (o *OpenMeteo) Forecast(ctx context.Context, city string, days int, _ ...weatherapi.Option) (*models.ForecastResponse, error) {
+	location, err := o.Geocode(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	query := coordinates(location)
+	query.Set("daily", dailyVariables)
+	query.Set("timezone", "auto")
+	query.Set("forecast_days", strconv.Itoa(min(max(days, 1), maxForecastDays)))
+
+	var data forecastResponse
+
+	if err := o.get(ctx, o.forecastURL+"/v1/forecast", query, &data); err != nil {
+		return nil, err
+	}
+
+	daily := data.Daily
+
+	response := &models.ForecastResponse{
+		Location: *location,
+	}
+
+	for i, date := range daily.Time {
+		maxTemp, minTemp := at(daily.TemperatureMax, i), at(daily.TemperatureMin, i)
+		avgTemp := (maxTemp + minTemp) / 2
+		precip, snow := at(daily.PrecipitationSum, i), at(daily.SnowfallSum, i)
+		maxWind := at(daily.WindSpeedMax, i)
+		chanceOfPrecip := at(daily.PrecipitationProbabilityMax, i)
+
+		var willItRain, chanceOfRain, willItSnow, chanceOfSnow int64
+		if snow > 0 {
+			willItSnow, chanceOfSnow = 1, chanceOfPrecip
+		} else {
+			chanceOfRain = chanceOfPrecip
+			if precip > 0 {
+				willItRain = 1
+			}
+		}
+
+		response.Forecast.ForecastDay = append(response.Forecast.ForecastDay, models.ForecastDay{
+			Date: date,
+			Day: models.Day{
+				MaxtempC:      maxTemp,
+				MaxtempF:      fahrenheit(maxTemp),
+				MintempC:      minTemp,
+				MintempF:      fahrenheit(minTemp),
+				AvgtempC:      avgTemp,
+				AvgtempF:      fahrenheit(avgTemp),
+				MaxwindKph:    maxWind,
+				MaxwindMph:    mph(maxWind),
+				TotalprecipMm: precip,
+				TotalprecipIn: math.Round(units.MillimetresToInches(precip)*100) / 100,
+				TotalsnowCm:   snow,
+				WillItRain:    willItRain,
+				ChanceOfRain:  chanceOfRain,
+				WillItSnow:    willItSnow,
+				ChanceOfSnow:  chanceOfSnow,
+				UV:            at(daily.UVIndexMax, i),
+				Condition:     weatherapi.NewCondition(conditionCode(at(daily.WeatherCode, i)), true),
+			},
+			Astro: models.Astro{
+				Sunrise: clock(at(daily.Sunrise, i)),
+				Sunset:  clock(at(daily.Sunset, i)),
+			},
+		})
+	}
+
+	return response, nil
+}
+
+// Alerts is not supported because Open-Meteo does not publish weather warnings.
+func (o *OpenMeteo) Alerts(_ context.Context, _ string) (*models.AlertsResponse, error) {
+	return nil, fmt.Errorf("open-meteo: weather alerts: %w", errors.ErrUnsupported)
+}
+
+func (o *OpenMeteo) get(ctx context.Context, endpoint string, query url.Values, data any) error {
+	request, err := http.NewRequestWithContext(ctx,
+		http.MethodGet,
+		endpoint+"?"+query.Encode(),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := o.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return &weatherapi.StatusError{API: "open-meteo", StatusCode: response.StatusCode}
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, data)
+}
+
+func coordinates(location *models.Location) url.Values {
+	return url.Values{
+		"latitude":  {strconv.FormatFloat(location.Lat, 'f', -1, 64)},
+		"longitude": {strconv.FormatFloat(location.Lon, 'f', -1, 64)},
+	}
+}
+
+// at returns the i-th value of a daily series, tolerating short series.
+func at[T any](values []T, i int) T {
+	var zero T
+	if i >= len(values) {
+		return zero
+	}
+
+	return values[i]
+}
+
+// clock converts an ISO 8601 local time to WeatherAPI's "06:14 AM" format.
+func clock(value string) string {
+	t, err := time.Parse("2006-01-02T15:04", value)
+	if err != nil {
+		return ""
+	}
+
+	return t.Format("03:04 PM")
+}
+
+// observedAt converts an ISO 8601 local time at the given offset from UTC
+// to Unix seconds, or 0 if it is not a time.
+func observedAt(value string, utcOffsetSeconds int64) int64 {
+	t, err := time.Parse("2006-01-02T15:04", value)
+	if err != nil {
+		return 0
+	}
+
+	return t.Unix() - utcOffsetSeconds
+}
+
+func fahrenheit(celsius float64) float64 {
+	return math.Round(units.CelsiusToFahrenheit(celsius)*10) / 10
+}
+
+func mph(kph float64) float64 {
+	return math.Round(units.KPHToMPH(kph)*10) / 10
+}
+
+// conditionCode maps a WMO weather interpretation code to a WeatherAPI condition code.
+func conditionCode(wmo int64) int64 {
+	switch wmo {
+	case 0:
+		return 1000
+	case 1, 2:
+		return 1003
+	case 3:
+		return 1009
+	case 45:
+		return 1135
+	case 48:
+		return 1147
+	case 51:
+		return 1150
+	case 53, 55:
+		return 1153
+	case 56:
+		return 1168
+	case 57:
+		return 1171
+	case 61:
+		return 1183
+	case 63:
+		return 1189
+	case 65:
+		return 1195
+	case 66:
+		return 1198
+	case 67:
+		return 1201
+	case 71:
+		return 1213
+	case 73:
+		return 1219
+	case 75:
+		return 1225
+	case 77:
+		return 1237
+	case 80:
+		return 1240
+	case 81:
+		return 1243
+	case 82:
+		return 1246
+	case 85:
+		return 1255
+	case 86:
+		return 1258
+	case 95, 96, 99:
+		return 1276
+	default:
+		return 0
+	}
+}
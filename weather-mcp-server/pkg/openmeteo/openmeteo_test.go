@@ -0,0 +1,282 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// newTestServer serves the geocoding and forecast fixtures from the mock directory.
+func newTestServer(t *testing.T) *OpenMeteo {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fixture string
+
+		switch query := r.URL.Query(); {
+		case r.URL.Path == "/v1/search" && query.Get("name") == "London":
+			fixture = "search.json"
+		case r.URL.Path == "/v1/search" && query.Get("name") == "Portland":
+			fixture = "search_portland.json"
+		case r.URL.Path == "/v1/search":
+			w.Write([]byte(`{"generationtime_ms": 0.3}`))
+			return
+		case r.URL.Path == "/v1/forecast" && query.Get("current") != "":
+			fixture = "current.json"
+		case r.URL.Path == "/v1/forecast" && query.Get("daily") != "":
+			fixture = "forecast.json"
+		default:
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("mock", fixture))
+		if err != nil {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return &OpenMeteo{
+		forecastURL:  server.URL,
+		geocodingURL: server.URL,
+		client:       server.Client(),
+	}
+}
+
+func TestCurrentWeather(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		city      string
+		errString string
+		wait      *models.CurrentResponse
+	}{
+		"successful_request": {
+			city: "London, UK",
+			wait: &models.CurrentResponse{
+				Location: models.Location{
+					Name:    "London",
+					Region:  "England",
+					Country: "United Kingdom",
+					Lat:     51.50853,
+					Lon:     -0.12574,
+					TzID:    "Europe/London",
+				},
+				Current: models.Current{
+					TempC:      18.4,
+					TempF:      65.1,
+					WindKph:    4,
+					WindMph:    2.5,
+					WindDir:    "WSW",
+					Humidity:   45,
+					FeelslikeC: 17.1,
+					FeelslikeF: 62.8,
+					Visibility: 24.14,
+					UV:         4.2,
+					GustKph:    9.4,
+					PressureMb: 1022.3,
+					Condition: models.Condition{
+						Text: "Sunny",
+						Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+						Code: 1000,
+					},
+					LastUpdatedEpoch: 1744372800,
+					LastUpdated:      "2025-04-11 13:00",
+				},
+			},
+		},
+		"coordinates_skip_geocoding": {
+			city: "51.5,-0.12",
+			wait: &models.CurrentResponse{
+				Location: models.Location{
+					Name: "51.5,-0.12",
+					Lat:  51.5,
+					Lon:  -0.12,
+					TzID: "Europe/London",
+				},
+				Current: models.Current{
+					TempC:      18.4,
+					TempF:      65.1,
+					WindKph:    4,
+					WindMph:    2.5,
+					WindDir:    "WSW",
+					Humidity:   45,
+					FeelslikeC: 17.1,
+					FeelslikeF: 62.8,
+					Visibility: 24.14,
+					UV:         4.2,
+					GustKph:    9.4,
+					PressureMb: 1022.3,
+					Condition: models.Condition{
+						Text: "Sunny",
+						Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+						Code: 1000,
+					},
+					LastUpdatedEpoch: 1744372800,
+					LastUpdated:      "2025-04-11 13:00",
+				},
+			},
+		},
+		"city_not_found": {
+			city:      "Atlantis",
+			errString: `location "Atlantis" not found`,
+		},
+		"location_id": {
+			city:      "id:2618724",
+			errString: `query "id:2618724": unsupported operation`,
+		},
+	}
+
+	openMeteo := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := openMeteo.Current(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestGeocode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		city       string
+		errString  string
+		waitRegion string
+		waitLat    float64
+	}{
+		"first_without_qualifier": {
+			city:       "Portland",
+			waitRegion: "Oregon",
+			waitLat:    45.52345,
+		},
+		"state_abbreviation": {
+			city:       "Portland, ME",
+			waitRegion: "Maine",
+			waitLat:    43.66147,
+		},
+		"region_and_country": {
+			city:       "Portland, victoria, AUSTRALIA",
+			waitRegion: "Victoria",
+			waitLat:    -38.34174,
+		},
+		"country_code": {
+			city:       "Portland, AU",
+			waitRegion: "Victoria",
+			waitLat:    -38.34174,
+		},
+		"no_place_matches_qualifier": {
+			city:      "Portland, TX",
+			errString: `location "Portland, TX" not found`,
+		},
+	}
+
+	openMeteo := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := openMeteo.Geocode(context.Background(), tc.city)
+			if tc.errString != "" {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, "Portland", result.Name)
+			assert.Equal(t, tc.waitRegion, result.Region)
+			assert.Equal(t, tc.waitLat, result.Lat)
+		})
+	}
+}
+
+func TestForecast(t *testing.T) {
+	t.Parallel()
+
+	openMeteo := newTestServer(t)
+
+	result, err := openMeteo.Forecast(context.Background(), "London", 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "London", result.Location.Name)
+	assert.Equal(t, []models.ForecastDay{
+		{
+			Date: "2025-04-11",
+			Day: models.Day{
+				MaxtempC:     19.6,
+				MaxtempF:     67.3,
+				MintempC:     7.2,
+				MintempF:     45,
+				AvgtempC:     13.4,
+				AvgtempF:     56.1,
+				MaxwindKph:   13.7,
+				MaxwindMph:   8.5,
+				ChanceOfRain: 3,
+				UV:           4.9,
+				Condition: models.Condition{
+					Text: "Sunny",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+					Code: 1000,
+				},
+			},
+			Astro: models.Astro{
+				Sunrise: "06:14 AM",
+				Sunset:  "07:55 PM",
+			},
+		},
+		{
+			Date: "2025-04-12",
+			Day: models.Day{
+				MaxtempC:      15,
+				MaxtempF:      59,
+				MintempC:      9,
+				MintempF:      48.2,
+				AvgtempC:      12,
+				AvgtempF:      53.6,
+				MaxwindKph:    22.5,
+				MaxwindMph:    14,
+				TotalprecipMm: 3.4,
+				TotalprecipIn: 0.13,
+				WillItRain:    1,
+				ChanceOfRain:  80,
+				UV:            2.1,
+				Condition: models.Condition{
+					Text: "Light rain",
+					Icon: "//cdn.weatherapi.com/weather/64x64/day/296.png",
+					Code: 1183,
+				},
+			},
+			Astro: models.Astro{
+				Sunrise: "06:12 AM",
+				Sunset:  "07:57 PM",
+			},
+		},
+	}, result.Forecast.ForecastDay)
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0).Alerts(context.Background(), "London")
+
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
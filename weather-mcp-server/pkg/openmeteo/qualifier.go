@@ -0,0 +1,76 @@
+package openmeteo
+
+import "strings"
+
+// geocodingCount is how many places a search asks for, so that a
+// qualified query such as "Portland, ME" can pick its match among the
+// places sharing the name.
+const geocodingCount = 10
+
+// countryAliases maps the country names people write to ISO 3166-1 codes
+// where they differ from the code itself.
+var countryAliases = map[string]string{
+	"uk":  "GB",
+	"usa": "US",
+}
+
+// usStates maps the USPS abbreviations to the state names Open-Meteo
+// reports as admin1.
+var usStates = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas", "CA": "California",
+	"CO": "Colorado", "CT": "Connecticut", "DE": "Delaware", "DC": "Washington, D.C.", "FL": "Florida",
+	"GA": "Georgia", "HI": "Hawaii", "ID": "Idaho", "IL": "Illinois", "IN": "Indiana",
+	"IA": "Iowa", "KS": "Kansas", "KY": "Kentucky", "LA": "Louisiana", "ME": "Maine",
+	"MD": "Maryland", "MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota", "MS": "Mississippi",
+	"MO": "Missouri", "MT": "Montana", "NE": "Nebraska", "NV": "Nevada", "NH": "New Hampshire",
+	"NJ": "New Jersey", "NM": "New Mexico", "NY": "New York", "NC": "North Carolina", "ND": "North Dakota",
+	"OH": "Ohio", "OK": "Oklahoma", "OR": "Oregon", "PA": "Pennsylvania", "RI": "Rhode Island",
+	"SC": "South Carolina", "SD": "South Dakota", "TN": "Tennessee", "TX": "Texas", "UT": "Utah",
+	"VT": "Vermont", "VA": "Virginia", "WA": "Washington", "WV": "West Virginia", "WI": "Wisconsin",
+	"WY": "Wyoming",
+}
+
+// qualifiers splits a query such as "Portland, ME, USA" into the name and
+// the parts that narrow it down.
+func qualifiers(city string) (string, []string) {
+	parts := strings.Split(city, ",")
+
+	var rest []string
+
+	for _, part := range parts[1:] {
+		if part = strings.TrimSpace(part); part != "" {
+			rest = append(rest, part)
+		}
+	}
+
+	return strings.TrimSpace(parts[0]), rest
+}
+
+// matches reports whether every qualifier names the place's region or
+// country, by name or by code.
+func (p place) matches(qualifiers []string) bool {
+	for _, qualifier := range qualifiers {
+		if !p.matchesOne(qualifier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p place) matchesOne(qualifier string) bool {
+	switch {
+	case strings.EqualFold(qualifier, p.Admin1),
+		strings.EqualFold(qualifier, p.Country),
+		strings.EqualFold(qualifier, p.CountryCode):
+		return true
+	}
+
+	if code, ok := countryAliases[strings.ToLower(qualifier)]; ok {
+		return code == p.CountryCode
+	}
+
+	state, ok := usStates[strings.ToUpper(qualifier)]
+
+	return ok && p.CountryCode == "US" && state == p.Admin1
+}
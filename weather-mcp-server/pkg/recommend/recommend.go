@@ -0,0 +1,282 @@
+// Package recommend suggests places to visit, fun facts and tips for the
+// weather in a city. The suggestions come from a catalog of rules, each
+// for a kind of suggestion and optionally a city, a weather category and
+// a season. A catalog is built in and can be extended from a JSON file.
+package recommend
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Kind is a kind of suggestion.
+type Kind string
+
+const (
+	// Place is a place to visit. One is picked for the weather.
+	Place Kind = "place"
+	// Fact is a fun fact about the city. One is picked for the weather.
+	Fact Kind = "fact"
+	// Advice is a list of recommendations for the weather.
+	Advice Kind = "advice"
+	// Travel is a list of travel and local tips.
+	Travel Kind = "travel"
+)
+
+type Season string
+
+const (
+	Spring Season = "spring"
+	Summer Season = "summer"
+	Autumn Season = "autumn"
+	Winter Season = "winter"
+)
+
+// DefaultLanguage is used for tips without a text in the requested language.
+const DefaultLanguage = "en"
+
+// catalogVersion is the only catalog file version understood.
+const catalogVersion = 1
+
+//go:embed catalog.json
+var builtin []byte
+
+var defaultCatalog = mustParse(builtin)
+
+// Text is a tip keyed by language code.
+type Text map[string]string
+
+// City is a city that rules refer to by its key in the catalog. It matches
+// a location by WeatherAPI location id or by name, ignoring case.
+type City struct {
+	IDs   []int64  `json:"ids,omitempty"`
+	Names []string `json:"names"`
+}
+
+// Rule holds the tips of one kind for a city, or for every city when City
+// is empty. Category and Season narrow the weather the rule applies to.
+type Rule struct {
+	Kind     Kind   `json:"kind"`
+	City     string `json:"city,omitempty"`
+	Category string `json:"category,omitempty"`
+	Season   Season `json:"season,omitempty"`
+	Tips     []Text `json:"tips"`
+}
+
+type ruleKey struct {
+	kind     Kind
+	city     string
+	category string
+	season   Season
+}
+
+func (r Rule) key() ruleKey {
+	return ruleKey{r.Kind, r.City, r.Category, r.Season}
+}
+
+type catalogFile struct {
+	Version int             `json:"version"`
+	Cities  map[string]City `json:"cities"`
+	Rules   []Rule          `json:"rules"`
+}
+
+// Catalog is a validated set of cities and rules.
+type Catalog struct {
+	cities map[string]City
+	rules  []Rule
+
+	index  map[ruleKey]int
+	byID   map[int64]string
+	byName map[string]string
+}
+
+// Default returns the built-in catalog.
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// Load returns the built-in catalog extended with the catalog file at path.
+// Cities in the file replace built-in cities with the same key, and rules
+// replace built-in rules with the same kind, city, category and season.
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parseFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	catalog, err := defaultCatalog.merge(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return catalog, nil
+}
+
+// Parse returns the catalog in data on its own, without the built-in rules.
+func Parse(data []byte) (*Catalog, error) {
+	file, err := parseFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&Catalog{}).merge(file)
+}
+
+func mustParse(data []byte) *Catalog {
+	catalog, err := Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("recommend: built-in catalog: %v", err))
+	}
+
+	return catalog
+}
+
+func parseFile(data []byte) (*catalogFile, error) {
+	var file catalogFile
+
+	if err := decodeStrict(data, &file); err != nil {
+		return nil, err
+	}
+
+	if file.Version != catalogVersion {
+		return nil, fmt.Errorf("unsupported catalog version %d", file.Version)
+	}
+
+	for i, rule := range file.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+
+	return &file, nil
+}
+
+func (r Rule) validate() error {
+	categories, ok := kindCategories[r.Kind]
+	if !ok {
+		return fmt.Errorf("unknown kind %q", r.Kind)
+	}
+
+	if r.Category != "" && !slices.Contains(categories, r.Category) {
+		return fmt.Errorf("%s has no category %q, use one of %s", r.Kind, r.Category, strings.Join(categories, ", "))
+	}
+
+	switch r.Season {
+	case "", Spring, Summer, Autumn, Winter:
+	default:
+		return fmt.Errorf("unknown season %q", r.Season)
+	}
+
+	if len(r.Tips) == 0 {
+		return errors.New("no tips")
+	}
+
+	for _, tip := range r.Tips {
+		if tip[DefaultLanguage] == "" {
+			return fmt.Errorf("a tip has no %q text", DefaultLanguage)
+		}
+	}
+
+	return nil
+}
+
+// merge returns a new catalog with the cities and rules of file laid over
+// those of c.
+func (c *Catalog) merge(file *catalogFile) (*Catalog, error) {
+	merged := &Catalog{
+		cities: make(map[string]City, len(c.cities)+len(file.Cities)),
+		rules:  slices.Clone(c.rules),
+		index:  make(map[ruleKey]int, len(c.rules)+len(file.Rules)),
+		byID:   make(map[int64]string),
+		byName: make(map[string]string),
+	}
+
+	for key, city := range c.cities {
+		merged.cities[key] = city
+	}
+
+	for key, city := range file.Cities {
+		merged.cities[key] = city
+	}
+
+	for i, rule := range merged.rules {
+		merged.index[rule.key()] = i
+	}
+
+	added := make(map[ruleKey]bool, len(file.Rules))
+
+	for i, rule := range file.Rules {
+		key := rule.key()
+		if added[key] {
+			return nil, fmt.Errorf("rule %d: another rule has the same kind, city, category and season", i+1)
+		}
+
+		added[key] = true
+
+		if _, ok := merged.cities[rule.City]; rule.City != "" && !ok {
+			return nil, fmt.Errorf("rule %d: unknown city %q", i+1, rule.City)
+		}
+
+		if existing, ok := merged.index[key]; ok {
+			merged.rules[existing] = rule
+			continue
+		}
+
+		merged.index[key] = len(merged.rules)
+		merged.rules = append(merged.rules, rule)
+	}
+
+	for key, city := range merged.cities {
+		for _, id := range city.IDs {
+			if other, ok := merged.byID[id]; ok {
+				return nil, fmt.Errorf("cities %q and %q share the id %d", min(key, other), max(key, other), id)
+			}
+
+			merged.byID[id] = key
+		}
+
+		for _, name := range city.Names {
+			name = normalize(name)
+			if other, ok := merged.byName[name]; ok {
+				return nil, fmt.Errorf("cities %q and %q share the name %q", min(key, other), max(key, other), name)
+			}
+
+			merged.byName[name] = key
+		}
+	}
+
+	return merged, nil
+}
+
+// decodeStrict decodes data into v, rejecting unknown fields so that a
+// misspelt key in a catalog file is reported rather than ignored.
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// text returns the tip in lang, or in the default language.
+func (t Text) text(lang string) string {
+	if text, ok := t[lang]; ok && text != "" {
+		return text
+	}
+
+	return t[DefaultLanguage]
+}
@@ -0,0 +1,251 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinTranslations(t *testing.T) {
+	t.Parallel()
+
+	for i, rule := range Default().rules {
+		for _, tip := range rule.Tips {
+			for _, lang := range []string{"en", "es", "fr", "de", "ja"} {
+				assert.NotEmpty(t, tip[lang], "rule %d has no %s text", i+1, lang)
+			}
+		}
+	}
+}
+
+func TestPlace(t *testing.T) {
+	t.Parallel()
+
+	january := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	july := time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		weather Weather
+		lang    string
+		wait    string
+	}{
+		"city_by_query": {
+			weather: Weather{Query: "tokyo", Code: 1003, TempC: 28},
+			wait:    "🏯 Visit the air-conditioned Tokyo National Museum",
+		},
+		"clear_sky_is_sunny": {
+			weather: Weather{Query: "Paris", Code: 1000, TempC: 2},
+			wait:    "🌉 Walk along the Seine River",
+		},
+		"rain_comes_first": {
+			weather: Weather{Query: "London", Code: 1240, TempC: 12},
+			wait:    "🏛️ Explore the Natural History Museum",
+		},
+		"city_by_id": {
+			weather: Weather{Query: "id:2801268", ID: 2801268, Code: 1009, TempC: 3},
+			wait:    "☕ Warm up in a traditional English pub",
+		},
+		"city_by_resolved_name": {
+			weather: Weather{Query: "51.52,-0.11", Name: "London", Code: 1009, TempC: 18},
+			wait:    "🌳 Enjoy Hyde Park and Kensington Gardens",
+		},
+		"unknown_city": {
+			weather: Weather{Query: "Reykjavik", Code: 1009, TempC: 8},
+			wait:    "🏛️ Explore local attractions",
+		},
+		"seasonal_rule": {
+			weather: Weather{Query: "Duluth", Lat: 46.78, Time: january, Code: 1009, TempC: -10},
+			wait:    "🎿 Ski or snowboard at Spirit Mountain",
+		},
+		"out_of_season": {
+			weather: Weather{Query: "Duluth", Lat: 46.78, Time: july, Code: 1009, TempC: -10},
+			wait:    "☕ Warm up in a cozy café",
+		},
+		"translated": {
+			weather: Weather{Query: "Cairo", Code: 1000, TempC: 35},
+			lang:    "de",
+			wait:    "🌊 Eine Nilkreuzfahrt machen",
+		},
+		"unknown_language": {
+			weather: Weather{Query: "Cairo", Code: 1000, TempC: 35},
+			lang:    "pt",
+			wait:    "🌊 Take a Nile River cruise",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.wait, Default().Place(tc.weather, tc.lang))
+		})
+	}
+}
+
+func TestFact(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		weather Weather
+		wait    string
+	}{
+		"city_condition": {
+			weather: Weather{Query: "Sydney", Code: 1150, TempC: 16},
+			wait:    "Rain in Sydney means the Opera House looks even more dramatic! 🎭",
+		},
+		"default_condition": {
+			weather: Weather{Query: "New York", Code: 1009, TempC: 16},
+			wait:    "Cloudy weather is great for photography and exploring indoor attractions! ☁️",
+		},
+		"temperature": {
+			weather: Weather{Query: "Paris", Code: 1030, TempC: 4},
+			wait:    "Cold weather! Great time for hot drinks and cozy indoor activities! ☕",
+		},
+		"city_fact": {
+			weather: Weather{Query: "Duluth", Code: 1030, TempC: 15},
+			wait:    "Duluth is home to the world's largest freshwater lake, Lake Superior! 🏞️",
+		},
+		"default_fact": {
+			weather: Weather{Query: "Lima", Code: 1030, TempC: 15},
+			wait:    "Every city has its unique charm and hidden gems to discover! ✨",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.wait, Default().Fact(tc.weather, "en"))
+		})
+	}
+}
+
+func TestAdvice(t *testing.T) {
+	t.Parallel()
+
+	weather := Weather{Query: "Paris", Code: 1189, TempC: 22, Humidity: 90, WindKph: 35}
+
+	assert.Equal(t, []string{
+		"☀️ Ideal temperature for outdoor activities",
+		"🚶 Great for walking tours and sightseeing",
+		"☔ Bring an umbrella or raincoat",
+		"🏛️ Perfect for museum visits and indoor attractions",
+		"💨 Strong winds - secure loose items",
+		"🏠 Consider indoor activities",
+		"💧 High humidity - stay hydrated",
+		"🌬️ Seek air-conditioned spaces",
+		"🗼 Climb the Eiffel Tower",
+		"☕ Experience café culture",
+	}, Default().Advice(weather, "en"))
+
+	blizzard := Weather{Query: "Lima", Code: 1117, TempC: -5}
+
+	assert.Equal(t, []string{
+		"🧣 Bundle up! Warm clothing essential",
+		"🔥 Great time for hot drinks and cozy indoor spots",
+		"🥾 Wear waterproof boots with a good grip",
+		"🚗 Allow extra time for travel on icy roads",
+	}, Default().Advice(blizzard, "en"))
+}
+
+func TestTravel(t *testing.T) {
+	t.Parallel()
+
+	weather := Weather{Query: "Tokyo", Code: 1000, TempC: 33}
+
+	assert.Equal(t, []string{
+		"🧴 **Travel Tip:** Bring sunscreen and stay hydrated",
+		"🚇 **Local Tip:** Use the efficient subway system to get around",
+		"🍜 **Food Tip:** Try local ramen shops for authentic cuisine",
+	}, Default().Travel(weather, "en"))
+}
+
+func TestSeason(t *testing.T) {
+	t.Parallel()
+
+	april := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	december := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, Spring, Weather{Lat: 48.86, Time: april}.Season())
+	assert.Equal(t, Autumn, Weather{Lat: -33.87, Time: april}.Season())
+	assert.Equal(t, Winter, Weather{Lat: 48.86, Time: december}.Season())
+	assert.Equal(t, Summer, Weather{Lat: -33.87, Time: december}.Season())
+	assert.Equal(t, Season(""), Weather{Lat: 48.86}.Season())
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		catalog   string
+		errString string
+	}{
+		"adds_and_replaces": {
+			catalog: `{
+				"version": 1,
+				"cities": {"reykjavik": {"ids": [2750871], "names": ["Reykjavik", "Reykjavík"]}},
+				"rules": [
+					{"kind": "place", "city": "reykjavik", "category": "cold", "tips": [{"en": "♨️ Soak in the Sky Lagoon"}]},
+					{"kind": "place", "city": "london", "category": "sunny", "tips": [{"en": "🚣 Row on the Serpentine"}]}
+				]
+			}`,
+		},
+		"unknown_version": {
+			catalog:   `{"version": 2}`,
+			errString: "unsupported catalog version 2",
+		},
+		"unknown_field": {
+			catalog:   `{"version": 1, "rules": [{"kind": "place", "town": "london", "tips": [{"en": "x"}]}]}`,
+			errString: `json: unknown field "town"`,
+		},
+		"unknown_category": {
+			catalog:   `{"version": 1, "rules": [{"kind": "fact", "category": "warm", "tips": [{"en": "x"}]}]}`,
+			errString: `rule 1: fact has no category "warm", use one of rainy, sunny, cloudy, hot, cold`,
+		},
+		"unknown_city": {
+			catalog:   `{"version": 1, "rules": [{"kind": "fact", "city": "atlantis", "tips": [{"en": "x"}]}]}`,
+			errString: `rule 1: unknown city "atlantis"`,
+		},
+		"missing_english": {
+			catalog:   `{"version": 1, "rules": [{"kind": "fact", "tips": [{"fr": "x"}]}]}`,
+			errString: `rule 1: a tip has no "en" text`,
+		},
+		"duplicate_rule": {
+			catalog: `{"version": 1, "rules": [
+				{"kind": "fact", "tips": [{"en": "x"}]},
+				{"kind": "fact", "tips": [{"en": "y"}]}
+			]}`,
+			errString: "rule 2: another rule has the same kind, city, category and season",
+		},
+		"shared_name": {
+			catalog:   `{"version": 1, "cities": {"london_ontario": {"names": ["London"]}}}`,
+			errString: `cities "london" and "london_ontario" share the name "london"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "catalog.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.catalog), 0o600))
+
+			catalog, err := Load(path)
+			if tc.errString != "" {
+				assert.EqualError(t, err, path+": "+tc.errString)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, "♨️ Soak in the Sky Lagoon", catalog.Place(Weather{ID: 2750871, Code: 1009, TempC: -2}, "en"))
+			assert.Equal(t, "♨️ Soak in the Sky Lagoon", catalog.Place(Weather{Query: "reykjavík", Code: 1009, TempC: -2}, "en"))
+			assert.Equal(t, "🚣 Row on the Serpentine", catalog.Place(Weather{Query: "London", Code: 1000, TempC: 20}, "en"))
+			assert.Equal(t, "🌉 Walk across Tower Bridge", Default().Place(Weather{Query: "London", Code: 1000, TempC: 20}, "en"))
+		})
+	}
+}
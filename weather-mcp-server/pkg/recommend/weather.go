@@ -0,0 +1,243 @@
+package recommend
+
+import (
+	"time"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/condition"
+)
+
+// Weather is what rules are matched against.
+type Weather struct {
+	// Query is the location as it was asked for and Name the place it
+	// resolved to. Either matches a city by name.
+	Query string
+	Name  string
+	// ID is the WeatherAPI location id of the place, zero if unknown.
+	ID int64
+	// Lat picks the hemisphere, and so the season, from Time.
+	Lat float64
+	// Time is the date of the weather. Rules for a season are skipped
+	// when it is zero.
+	Time time.Time
+	// Code is the WeatherAPI condition code, which is classified rather
+	// than the condition text so that any language works.
+	Code     int64
+	TempC    float64
+	Humidity int
+	WindKph  float64
+}
+
+// kindCategories lists the weather categories each kind of rule can have,
+// in the order lists of tips are built.
+var kindCategories = map[Kind][]string{
+	Place:  {"hot", "warm", "cool", "cold", "rainy", "sunny"},
+	Fact:   {"rainy", "sunny", "cloudy", "hot", "cold"},
+	Advice: {"hot", "warm", "mild", "cold", "rainy", "snowy", "sunny", "cloudy", "windy", "humid"},
+	Travel: {"rainy", "sunny", "hot", "cold"},
+}
+
+// Place returns a place to visit in the city for the weather.
+func (c *Catalog) Place(w Weather, lang string) string {
+	return c.pick(Place, w, lang, placeCategory(w))
+}
+
+// Fact returns a fun fact about the city that suits the weather.
+func (c *Catalog) Fact(w Weather, lang string) string {
+	return c.pick(Fact, w, lang, factCategory(w), "")
+}
+
+// Advice returns recommendations for the weather, followed by those for
+// the city.
+func (c *Catalog) Advice(w Weather, lang string) []string {
+	return c.collect(Advice, w, lang, adviceCategories(w))
+}
+
+// Travel returns travel tips for the weather, followed by local tips for
+// the city.
+func (c *Catalog) Travel(w Weather, lang string) []string {
+	var categories []string
+	if category := travelCategory(w); category != "" {
+		categories = append(categories, category)
+	}
+
+	return c.collect(Travel, w, lang, categories)
+}
+
+// Season returns the season of the weather's date in its hemisphere, or
+// an empty season when the date is unknown.
+func (w Weather) Season() Season {
+	if w.Time.IsZero() {
+		return ""
+	}
+
+	month := int(w.Time.Month())
+	if w.Lat < 0 {
+		month = (month+5)%12 + 1
+	}
+
+	switch month {
+	case 3, 4, 5:
+		return Spring
+	case 6, 7, 8:
+		return Summer
+	case 9, 10, 11:
+		return Autumn
+	default:
+		return Winter
+	}
+}
+
+func placeCategory(w Weather) string {
+	class := condition.Classify(w.Code)
+
+	switch {
+	case class.Rainy():
+		return "rainy"
+	case class.Kind == condition.Clear:
+		return "sunny"
+	case w.TempC >= 25:
+		return "hot"
+	case w.TempC >= 15:
+		return "warm"
+	case w.TempC >= 5:
+		return "cool"
+	default:
+		return "cold"
+	}
+}
+
+func factCategory(w Weather) string {
+	class := condition.Classify(w.Code)
+
+	switch {
+	case class.Rainy():
+		return "rainy"
+	case class.Kind == condition.Clear:
+		return "sunny"
+	case class.Clouded():
+		return "cloudy"
+	case w.TempC > 30:
+		return "hot"
+	case w.TempC < 10:
+		return "cold"
+	default:
+		return ""
+	}
+}
+
+func adviceCategories(w Weather) []string {
+	var categories []string
+
+	switch {
+	case w.TempC > 30:
+		categories = append(categories, "hot")
+	case w.TempC > 20:
+		categories = append(categories, "warm")
+	case w.TempC > 10:
+		categories = append(categories, "mild")
+	default:
+		categories = append(categories, "cold")
+	}
+
+	switch class := condition.Classify(w.Code); {
+	case class.Rainy():
+		categories = append(categories, "rainy")
+	case class.Wintry():
+		categories = append(categories, "snowy")
+	case class.Kind == condition.Clear:
+		categories = append(categories, "sunny")
+	case class.Clouded():
+		categories = append(categories, "cloudy")
+	}
+
+	if w.WindKph > 30 {
+		categories = append(categories, "windy")
+	}
+
+	if w.Humidity > 80 {
+		categories = append(categories, "humid")
+	}
+
+	return categories
+}
+
+func travelCategory(w Weather) string {
+	class := condition.Classify(w.Code)
+
+	switch {
+	case class.Rainy():
+		return "rainy"
+	case class.Kind == condition.Clear:
+		return "sunny"
+	case w.TempC > 30:
+		return "hot"
+	case w.TempC < 10:
+		return "cold"
+	default:
+		return ""
+	}
+}
+
+// city returns the key of the catalog city the weather is for, or an empty
+// key for a city the catalog does not know.
+func (c *Catalog) city(w Weather) string {
+	if key, ok := c.byID[w.ID]; ok && w.ID != 0 {
+		return key
+	}
+
+	for _, name := range []string{w.Query, w.Name} {
+		if key, ok := c.byName[normalize(name)]; ok {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// pick returns a tip from the most specific rule for the first category
+// that has one. Rules for the city come before rules for every city, and
+// rules for the season before rules for the whole year. A rule with
+// several tips gives a different one each day.
+func (c *Catalog) pick(kind Kind, w Weather, lang string, categories ...string) string {
+	city, season := c.city(w), w.Season()
+
+	for _, category := range categories {
+		for _, key := range []ruleKey{
+			{kind, city, category, season},
+			{kind, city, category, ""},
+			{kind, "", category, season},
+			{kind, "", category, ""},
+		} {
+			if i, ok := c.index[key]; ok {
+				tips := c.rules[i].Tips
+				return tips[w.Time.YearDay()%len(tips)].text(lang)
+			}
+		}
+	}
+
+	return ""
+}
+
+// collect returns the tips of every rule that applies to the weather, by
+// category in order, then the rules without a category.
+func (c *Catalog) collect(kind Kind, w Weather, lang string, categories []string) []string {
+	city, season := c.city(w), w.Season()
+
+	var tips []string
+
+	for _, category := range append(categories, "") {
+		for _, rule := range c.rules {
+			if rule.Kind != kind || rule.Category != category ||
+				(rule.City != "" && rule.City != city) ||
+				(rule.Season != "" && rule.Season != season) {
+				continue
+			}
+
+			for _, tip := range rule.Tips {
+				tips = append(tips, tip.text(lang))
+			}
+		}
+	}
+
+	return tips
+}
@@ -0,0 +1,161 @@
+// Package stats computes weather statistics over a window of observed days.
+package stats
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/condition"
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+// stableSlope is the trend slope in °C per day below which the
+// temperature is considered stable.
+const stableSlope = 0.1
+
+var ErrNoData = errors.New("no days to compute statistics from")
+
+type Percentiles struct {
+	P10 float64 `json:"p10"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P90 float64 `json:"p90"`
+}
+
+type Trend struct {
+	SlopePerDay float64 `json:"slope_per_day"`
+	Direction   string  `json:"direction"`
+}
+
+type Summary struct {
+	Days                   int         `json:"days"`
+	MeanTemperature        float64     `json:"mean_temperature"`
+	MinTemperature         float64     `json:"min_temperature"`
+	MaxTemperature         float64     `json:"max_temperature"`
+	TemperaturePercentiles Percentiles `json:"temperature_percentiles"`
+	MeanHumidity           float64     `json:"mean_humidity"`
+	TotalPrecipMm          float64     `json:"total_precip_mm"`
+	RainyDays              int         `json:"rainy_days"`
+	SunnyDays              int         `json:"sunny_days"`
+	TemperatureTrend       Trend       `json:"temperature_trend"`
+}
+
+// Compute summarises daily aggregates, using the average daily temperature
+// (°C) for the mean, percentiles and trend.
+func Compute(days []models.ForecastDay) (*Summary, error) {
+	if len(days) == 0 {
+		return nil, ErrNoData
+	}
+
+	summary := Summary{
+		Days:           len(days),
+		MinTemperature: math.Inf(1),
+		MaxTemperature: math.Inf(-1),
+	}
+
+	temps := make([]float64, 0, len(days))
+
+	var humidity float64
+
+	for _, day := range days {
+		temps = append(temps, day.Day.AvgtempC)
+		humidity += float64(day.Day.AvgHumidity)
+
+		summary.MinTemperature = math.Min(summary.MinTemperature, day.Day.MintempC)
+		summary.MaxTemperature = math.Max(summary.MaxTemperature, day.Day.MaxtempC)
+		summary.TotalPrecipMm += day.Day.TotalprecipMm
+
+		switch class := condition.Classify(day.Day.Condition.Code); {
+		case class.Rainy():
+			summary.RainyDays++
+		case class.Kind == condition.Clear:
+			summary.SunnyDays++
+		}
+	}
+
+	summary.MeanTemperature = Mean(temps)
+	summary.MeanHumidity = humidity / float64(len(days))
+
+	sorted := append([]float64(nil), temps...)
+	sort.Float64s(sorted)
+
+	summary.TemperaturePercentiles = Percentiles{
+		P10: Percentile(sorted, 10),
+		P25: Percentile(sorted, 25),
+		P50: Percentile(sorted, 50),
+		P75: Percentile(sorted, 75),
+		P90: Percentile(sorted, 90),
+	}
+
+	slope, _ := LinearRegression(temps)
+
+	summary.TemperatureTrend = Trend{
+		SlopePerDay: slope,
+		Direction:   direction(slope),
+	}
+
+	return &summary, nil
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted values using
+// linear interpolation between the closest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// LinearRegression fits values against their index with least squares
+// and returns the slope and intercept.
+func LinearRegression(values []float64) (slope, intercept float64) {
+	n := float64(len(values))
+	if n < 2 {
+		return 0, Mean(values)
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	slope = (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	intercept = (sumY - slope*sumX) / n
+
+	return slope, intercept
+}
+
+func direction(slope float64) string {
+	switch {
+	case slope > stableSlope:
+		return "increasing"
+	case slope < -stableSlope:
+		return "decreasing"
+	default:
+		return "stable"
+	}
+}
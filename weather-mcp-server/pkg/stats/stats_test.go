@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func day(avg, min, max float64, code int64) models.ForecastDay {
+	return models.ForecastDay{
+		Day: models.Day{
+			AvgtempC:      avg,
+			MintempC:      min,
+			MaxtempC:      max,
+			AvgHumidity:   60,
+			TotalprecipMm: 1.5,
+			Condition: models.Condition{
+				Code: code,
+			},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		days      []models.ForecastDay
+		errString string
+		wait      *Summary
+	}{
+		"no_days": {
+			errString: "no days to compute statistics from",
+		},
+		"warming_week": {
+			days: []models.ForecastDay{
+				day(10, 5, 14, 1000),
+				day(12, 6, 16, 1183),
+				day(14, 8, 19, 1003),
+				day(16, 9, 21, 1000),
+				day(18, 11, 24, 1276),
+			},
+			wait: &Summary{
+				Days:            5,
+				MeanTemperature: 14,
+				MinTemperature:  5,
+				MaxTemperature:  24,
+				TemperaturePercentiles: Percentiles{
+					P10: 10.8,
+					P25: 12,
+					P50: 14,
+					P75: 16,
+					P90: 17.2,
+				},
+				MeanHumidity:  60,
+				TotalPrecipMm: 7.5,
+				RainyDays:     2,
+				SunnyDays:     2,
+				TemperatureTrend: Trend{
+					SlopePerDay: 2,
+					Direction:   "increasing",
+				},
+			},
+		},
+		"single_day": {
+			days: []models.ForecastDay{
+				day(10, 5, 14, 1009),
+			},
+			wait: &Summary{
+				Days:            1,
+				MeanTemperature: 10,
+				MinTemperature:  5,
+				MaxTemperature:  14,
+				TemperaturePercentiles: Percentiles{
+					P10: 10, P25: 10, P50: 10, P75: 10, P90: 10,
+				},
+				MeanHumidity:  60,
+				TotalPrecipMm: 1.5,
+				TemperatureTrend: Trend{
+					Direction: "stable",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			summary, err := Compute(tc.days)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+
+			assert.Equal(t, tc.wait, summary)
+		})
+	}
+}
+
+func TestLinearRegression(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values    []float64
+		slope     float64
+		intercept float64
+	}{
+		"empty":      {values: nil, slope: 0, intercept: 0},
+		"flat":       {values: []float64{3, 3, 3}, slope: 0, intercept: 3},
+		"decreasing": {values: []float64{9, 7, 5, 3}, slope: -2, intercept: 9},
+		"noisy":      {values: []float64{1, 3, 2, 4}, slope: 0.8, intercept: 1.3},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			slope, intercept := LinearRegression(tc.values)
+
+			assert.InDelta(t, tc.slope, slope, 1e-9)
+			assert.InDelta(t, tc.intercept, intercept, 1e-9)
+		})
+	}
+}
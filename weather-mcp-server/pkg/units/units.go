@@ -0,0 +1,190 @@
+// Package units converts and formats weather quantities, which the
+// providers report in metric units, for the unit system a user asked for.
+package units
+
+import "fmt"
+
+type Temperature string
+
+const (
+	Celsius    Temperature = "celsius"
+	Fahrenheit Temperature = "fahrenheit"
+	Kelvin     Temperature = "kelvin"
+)
+
+type Speed string
+
+const (
+	KPH      Speed = "kph"
+	MPH      Speed = "mph"
+	MPS      Speed = "mps"
+	Knots    Speed = "knots"
+	Beaufort Speed = "beaufort"
+)
+
+type Pressure string
+
+const (
+	Millibars       Pressure = "mb"
+	InchesOfMercury Pressure = "inhg"
+	Hectopascals    Pressure = "hpa"
+)
+
+type Precipitation string
+
+const (
+	Millimetres Precipitation = "mm"
+	Inches      Precipitation = "in"
+)
+
+type Height string
+
+const (
+	Metres Height = "m"
+	Feet   Height = "ft"
+)
+
+// Set is the unit used for each quantity.
+type Set struct {
+	Temperature   Temperature
+	Wind          Speed
+	Pressure      Pressure
+	Precipitation Precipitation
+	Height        Height
+}
+
+var (
+	Metric     = Set{Celsius, KPH, Millibars, Millimetres, Metres}
+	Imperial   = Set{Fahrenheit, MPH, InchesOfMercury, Inches, Feet}
+	UK         = Set{Celsius, MPH, Millibars, Millimetres, Metres}
+	Scientific = Set{Kelvin, MPS, Hectopascals, Millimetres, Metres}
+)
+
+var systems = map[string]Set{
+	"metric":     Metric,
+	"imperial":   Imperial,
+	"uk":         UK,
+	"scientific": Scientific,
+}
+
+// Systems returns the names of the unit systems accepted by Lookup.
+func Systems() []string {
+	return []string{"metric", "imperial", "uk", "scientific"}
+}
+
+// Lookup returns the unit system with the given name.
+func Lookup(system string) (Set, bool) {
+	set, ok := systems[system]
+
+	return set, ok
+}
+
+// WindUnits returns the wind speed units accepted by WithWind.
+func WindUnits() []string {
+	return []string{string(KPH), string(MPH), string(MPS), string(Knots), string(Beaufort)}
+}
+
+// WithWind returns the set with wind speeds in the given unit.
+func (s Set) WithWind(unit string) (Set, bool) {
+	switch speed := Speed(unit); speed {
+	case KPH, MPH, MPS, Knots, Beaufort:
+		s.Wind = speed
+		return s, true
+	default:
+		return s, false
+	}
+}
+
+func CelsiusToFahrenheit(c float64) float64  { return c*9/5 + 32 }
+func CelsiusToKelvin(c float64) float64      { return c + 273.15 }
+func KPHToMPH(kph float64) float64           { return kph / 1.609344 }
+func KPHToMPS(kph float64) float64           { return kph / 3.6 }
+func KPHToKnots(kph float64) float64         { return kph / 1.852 }
+func MillibarsToInHg(mb float64) float64     { return mb * 0.02953 }
+func MillimetresToInches(mm float64) float64 { return mm / 25.4 }
+func MetresToFeet(m float64) float64         { return m / 0.3048 }
+
+// beaufortLimits are the lowest wind speeds in km/h of forces 1 to 12.
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+// BeaufortForce returns the Beaufort force, from 0 to 12, of a wind speed.
+func BeaufortForce(kph float64) int {
+	for force, limit := range beaufortLimits {
+		if kph < limit {
+			return force
+		}
+	}
+
+	return len(beaufortLimits)
+}
+
+// FormatTemperature formats a temperature given in degrees Celsius.
+func (s Set) FormatTemperature(c float64) string {
+	switch s.Temperature {
+	case Fahrenheit:
+		return fmt.Sprintf("%.0f°F", CelsiusToFahrenheit(c))
+	case Kelvin:
+		return fmt.Sprintf("%.1f K", CelsiusToKelvin(c))
+	default:
+		return fmt.Sprintf("%.0f°C", c)
+	}
+}
+
+// FormatTemperatureDifference formats a difference between two
+// temperatures given in degrees Celsius.
+func (s Set) FormatTemperatureDifference(c float64) string {
+	switch s.Temperature {
+	case Fahrenheit:
+		return fmt.Sprintf("%.1f°F", c*9/5)
+	case Kelvin:
+		return fmt.Sprintf("%.1f K", c)
+	default:
+		return fmt.Sprintf("%.1f°C", c)
+	}
+}
+
+// FormatWind formats a wind speed given in km/h.
+func (s Set) FormatWind(kph float64) string {
+	switch s.Wind {
+	case MPH:
+		return fmt.Sprintf("%.0f mph", KPHToMPH(kph))
+	case MPS:
+		return fmt.Sprintf("%.1f m/s", KPHToMPS(kph))
+	case Knots:
+		return fmt.Sprintf("%.0f kn", KPHToKnots(kph))
+	case Beaufort:
+		return fmt.Sprintf("force %d", BeaufortForce(kph))
+	default:
+		return fmt.Sprintf("%.0f km/h", kph)
+	}
+}
+
+// FormatPressure formats an air pressure given in millibars.
+func (s Set) FormatPressure(mb float64) string {
+	switch s.Pressure {
+	case InchesOfMercury:
+		return fmt.Sprintf("%.2f inHg", MillibarsToInHg(mb))
+	case Hectopascals:
+		return fmt.Sprintf("%.0f hPa", mb)
+	default:
+		return fmt.Sprintf("%.0f mb", mb)
+	}
+}
+
+// FormatPrecipitation formats an amount of precipitation given in millimetres.
+func (s Set) FormatPrecipitation(mm float64) string {
+	if s.Precipitation == Inches {
+		return fmt.Sprintf("%.2f in", MillimetresToInches(mm))
+	}
+
+	return fmt.Sprintf("%.1f mm", mm)
+}
+
+// FormatHeight formats a height, such as that of a wave, given in metres.
+func (s Set) FormatHeight(m float64) string {
+	if s.Height == Feet {
+		return fmt.Sprintf("%.1f ft", MetresToFeet(m))
+	}
+
+	return fmt.Sprintf("%.1f m", m)
+}
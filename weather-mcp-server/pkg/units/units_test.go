@@ -0,0 +1,102 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		set                                                   Set
+		temperature, difference, wind, pressure, rain, height string
+	}{
+		"metric": {
+			set:         Metric,
+			temperature: "18°C",
+			difference:  "1.5°C",
+			wind:        "24 km/h",
+			pressure:    "1013 mb",
+			rain:        "4.2 mm",
+			height:      "1.2 m",
+		},
+		"imperial": {
+			set:         Imperial,
+			temperature: "65°F",
+			difference:  "2.7°F",
+			wind:        "15 mph",
+			pressure:    "29.91 inHg",
+			rain:        "0.17 in",
+			height:      "3.9 ft",
+		},
+		"uk": {
+			set:         UK,
+			temperature: "18°C",
+			difference:  "1.5°C",
+			wind:        "15 mph",
+			pressure:    "1013 mb",
+			rain:        "4.2 mm",
+			height:      "1.2 m",
+		},
+		"scientific": {
+			set:         Scientific,
+			temperature: "291.6 K",
+			difference:  "1.5 K",
+			wind:        "6.7 m/s",
+			pressure:    "1013 hPa",
+			rain:        "4.2 mm",
+			height:      "1.2 m",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.temperature, tc.set.FormatTemperature(18.42))
+			assert.Equal(t, tc.difference, tc.set.FormatTemperatureDifference(1.5))
+			assert.Equal(t, tc.wind, tc.set.FormatWind(24.1))
+			assert.Equal(t, tc.pressure, tc.set.FormatPressure(1012.8))
+			assert.Equal(t, tc.rain, tc.set.FormatPrecipitation(4.2))
+			assert.Equal(t, tc.height, tc.set.FormatHeight(1.2))
+		})
+	}
+}
+
+func TestWithWind(t *testing.T) {
+	t.Parallel()
+
+	knots, ok := Metric.WithWind("knots")
+	assert.True(t, ok)
+	assert.Equal(t, "13 kn", knots.FormatWind(24.1))
+	assert.Equal(t, Celsius, knots.Temperature)
+
+	beaufort, ok := Imperial.WithWind("beaufort")
+	assert.True(t, ok)
+	assert.Equal(t, "force 4", beaufort.FormatWind(24.1))
+
+	_, ok = Metric.WithWind("furlongs")
+	assert.False(t, ok)
+}
+
+func TestBeaufortForce(t *testing.T) {
+	t.Parallel()
+
+	for kph, force := range map[float64]int{0: 0, 0.9: 0, 1: 1, 11.9: 2, 38: 5, 117.9: 11, 118: 12, 200: 12} {
+		assert.Equal(t, force, BeaufortForce(kph), kph)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	for _, system := range Systems() {
+		_, ok := Lookup(system)
+		assert.True(t, ok, system)
+	}
+
+	_, ok := Lookup("nautical")
+	assert.False(t, ok)
+}
@@ -0,0 +1,220 @@
+package weatherapi
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var ErrBudgetLow = errors.New("weather API call budget is nearly used up, only cached data is served")
+
+// QuotaStatus reports the calls spent against the budget. A zero limit is
+// unlimited and has no remaining count.
+type QuotaStatus struct {
+	DailyLimit       int64  `json:"daily_limit"`
+	DailyUsed        int64  `json:"daily_used"`
+	DailyRemaining   *int64 `json:"daily_remaining"`
+	MonthlyLimit     int64  `json:"monthly_limit"`
+	MonthlyUsed      int64  `json:"monthly_used"`
+	MonthlyRemaining *int64 `json:"monthly_remaining"`
+	// Exhausted is set when the provider rejected the key for exceeding
+	// its monthly quota, until the month changes.
+	Exhausted bool `json:"exhausted"`
+	// Degraded is set when the remaining budget is within the reserve and
+	// upstream calls are refused.
+	Degraded     bool   `json:"degraded"`
+	PersistError string `json:"persist_error,omitempty"`
+}
+
+type budgetState struct {
+	Day        string `json:"day"`
+	DayCalls   int64  `json:"day_calls"`
+	Month      string `json:"month"`
+	MonthCalls int64  `json:"month_calls"`
+	Exhausted  bool   `json:"exhausted"`
+}
+
+// Budget counts upstream calls per UTC day and month and refuses new calls
+// once the remaining budget falls within the reserve.
+type Budget struct {
+	mu sync.Mutex
+
+	path           string
+	daily, monthly int64
+	reserve        float64
+	state          budgetState
+	persistErr     error
+	now            func() time.Time
+}
+
+// NewBudget returns a budget with daily and monthly limits, where zero is
+// unlimited. reserve is the fraction of each limit kept back: with a daily
+// limit of 100 and a reserve of 0.1, calls stop when 10 remain. The counts
+// are kept in the file at path so that they survive restarts; an empty
+// path keeps them in memory.
+func NewBudget(path string, daily, monthly int64, reserve float64) (*Budget, error) {
+	b := &Budget{
+		path:    path,
+		daily:   daily,
+		monthly: monthly,
+		reserve: reserve,
+		now:     time.Now,
+	}
+
+	if path == "" {
+		return b, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &b.state); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Spend records one upstream call, or returns ErrBudgetLow without
+// recording it.
+func (b *Budget) Spend() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+
+	if b.low() {
+		return ErrBudgetLow
+	}
+
+	b.state.DayCalls++
+	b.state.MonthCalls++
+	b.save()
+
+	return nil
+}
+
+// Exhaust records that the provider rejected the key for exceeding its
+// monthly quota.
+func (b *Budget) Exhaust() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+
+	b.state.Exhausted = true
+	b.save()
+}
+
+func (b *Budget) Low() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+
+	return b.low()
+}
+
+func (b *Budget) Status() QuotaStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+
+	status := QuotaStatus{
+		DailyLimit:       b.daily,
+		DailyUsed:        b.state.DayCalls,
+		DailyRemaining:   remaining(b.daily, b.state.DayCalls),
+		MonthlyLimit:     b.monthly,
+		MonthlyUsed:      b.state.MonthCalls,
+		MonthlyRemaining: remaining(b.monthly, b.state.MonthCalls),
+		Exhausted:        b.state.Exhausted,
+		Degraded:         b.low(),
+	}
+
+	if b.persistErr != nil {
+		status.PersistError = b.persistErr.Error()
+	}
+
+	return status
+}
+
+// roll resets the counters when the UTC day or month has changed.
+func (b *Budget) roll() {
+	now := b.now().UTC()
+
+	if day := now.Format(time.DateOnly); b.state.Day != day {
+		b.state.Day, b.state.DayCalls = day, 0
+	}
+
+	if month := now.Format("2006-01"); b.state.Month != month {
+		b.state.Month, b.state.MonthCalls, b.state.Exhausted = month, 0, false
+	}
+}
+
+func (b *Budget) low() bool {
+	return b.state.Exhausted ||
+		b.within(b.daily, b.state.DayCalls) ||
+		b.within(b.monthly, b.state.MonthCalls)
+}
+
+// within reports whether the calls left under limit are within the reserve.
+func (b *Budget) within(limit, used int64) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	return limit-used <= int64(math.Ceil(float64(limit)*b.reserve))
+}
+
+// save writes the counters through a temporary file so that a crash never
+// leaves a truncated file. A failure is reported in the status rather than
+// failing the call, which has already been counted in memory.
+func (b *Budget) save() {
+	if b.path == "" {
+		return
+	}
+
+	b.persistErr = func() error {
+		data, err := json.Marshal(b.state)
+		if err != nil {
+			return err
+		}
+
+		tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			return err
+		}
+
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+
+		return os.Rename(tmp.Name(), b.path)
+	}()
+}
+
+func remaining(limit, used int64) *int64 {
+	if limit <= 0 {
+		return nil
+	}
+
+	left := max(limit-used, 0)
+
+	return &left
+}
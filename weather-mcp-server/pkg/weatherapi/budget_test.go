@@ -0,0 +1,204 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		daily, monthly int64
+		reserve        float64
+		calls          int
+		spent          int64
+		degraded       bool
+	}{
+		"unlimited": {
+			calls: 5,
+			spent: 5,
+		},
+		"daily_limit_without_reserve": {
+			daily: 3,
+			calls: 5,
+			spent: 3,
+			// The limit has been reached, so nothing is left to spend.
+			degraded: true,
+		},
+		"reserve_is_kept_back": {
+			daily:    10,
+			reserve:  0.2,
+			calls:    10,
+			spent:    8,
+			degraded: true,
+		},
+		"monthly_limit": {
+			daily:    100,
+			monthly:  4,
+			reserve:  0.25,
+			calls:    5,
+			spent:    3,
+			degraded: true,
+		},
+		"within_budget": {
+			daily:   100,
+			monthly: 1000,
+			reserve: 0.1,
+			calls:   5,
+			spent:   5,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			budget, err := NewBudget("", tc.daily, tc.monthly, tc.reserve)
+			require.NoError(t, err)
+
+			var spent int64
+
+			for range tc.calls {
+				if err := budget.Spend(); err == nil {
+					spent++
+				} else {
+					assert.ErrorIs(t, err, ErrBudgetLow)
+				}
+			}
+
+			assert.Equal(t, tc.spent, spent)
+			assert.Equal(t, tc.degraded, budget.Low())
+		})
+	}
+}
+
+func TestBudgetRollsOver(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 4, 30, 23, 0, 0, 0, time.UTC)
+
+	budget, err := NewBudget("", 2, 3, 0)
+	require.NoError(t, err)
+	budget.now = func() time.Time { return now }
+
+	require.NoError(t, budget.Spend())
+	require.NoError(t, budget.Spend())
+	assert.ErrorIs(t, budget.Spend(), ErrBudgetLow)
+
+	now = now.Add(2 * time.Hour)
+
+	require.NoError(t, budget.Spend(), "a new day and month reset the counters")
+
+	budget.Exhaust()
+	assert.True(t, budget.Status().Exhausted)
+	assert.ErrorIs(t, budget.Spend(), ErrBudgetLow)
+
+	now = now.AddDate(0, 1, 0)
+
+	assert.False(t, budget.Status().Exhausted, "a new month clears exhaustion")
+}
+
+func TestBudgetPersists(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "quota.json")
+
+	budget, err := NewBudget(path, 100, 1000, 0)
+	require.NoError(t, err)
+
+	for range 3 {
+		require.NoError(t, budget.Spend())
+	}
+
+	restarted, err := NewBudget(path, 100, 1000, 0)
+	require.NoError(t, err)
+
+	status := restarted.Status()
+
+	remainingDaily, remainingMonthly := int64(97), int64(997)
+
+	assert.Equal(t, QuotaStatus{
+		DailyLimit:       100,
+		DailyUsed:        3,
+		DailyRemaining:   &remainingDaily,
+		MonthlyLimit:     1000,
+		MonthlyUsed:      3,
+		MonthlyRemaining: &remainingMonthly,
+	}, status)
+}
+
+func TestClientBudget(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Query().Get("q") == "Quota" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`))
+			return
+		}
+
+		w.Write([]byte(`{"location":{"name":"London"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	budget, err := NewBudget("", 0, 0, 0)
+	require.NoError(t, err)
+
+	weatherAPI := New("key", time.Second, WithBudget(budget), WithRateLimit(1000, 1))
+	weatherAPI.baseURL = server.URL
+
+	_, err = weatherAPI.Current(context.Background(), "London")
+	require.NoError(t, err)
+
+	_, err = weatherAPI.Current(context.Background(), "Quota")
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+
+	_, err = weatherAPI.Current(context.Background(), "London")
+	assert.ErrorIs(t, err, ErrBudgetLow)
+
+	assert.Equal(t, int64(2), requests.Load(), "calls over budget never reach the provider")
+	assert.Equal(t, int64(2), budget.Status().DailyUsed)
+}
+
+func TestRetriesSpendBudgetOnce(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"location":{"name":"London"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	budget, err := NewBudget("", 0, 0, 0)
+	require.NoError(t, err)
+
+	weatherAPI := New("key", time.Second, WithBudget(budget), WithRetries(3, time.Millisecond))
+	weatherAPI.baseURL = server.URL
+
+	_, err = weatherAPI.Current(context.Background(), "London")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), requests.Load())
+	assert.Equal(t, int64(1), budget.Status().DailyUsed, "one request spends the budget once")
+}
@@ -0,0 +1,66 @@
+package weatherapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
+)
+
+func TestNewCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		code  int64
+		isDay bool
+		wait  models.Condition
+	}{
+		"sunny_day": {
+			code:  1000,
+			isDay: true,
+			wait: models.Condition{
+				Text: "Sunny",
+				Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+				Code: 1000,
+			},
+		},
+		"clear_night": {
+			code: 1000,
+			wait: models.Condition{
+				Text: "Clear",
+				Icon: "//cdn.weatherapi.com/weather/64x64/night/113.png",
+				Code: 1000,
+			},
+		},
+		"unknown_code": {
+			code: 42,
+			wait: models.Condition{Code: 42},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, NewCondition(tc.code, tc.isDay))
+		})
+	}
+}
+
+func TestWindDirection(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[float64]string{
+		0:     "N",
+		11.2:  "N",
+		11.3:  "NNE",
+		255:   "WSW",
+		350:   "N",
+		360:   "N",
+		-90:   "W",
+		202.5: "SSW",
+	}
+
+	for degrees, wait := range testCases {
+		assert.Equal(t, wait, WindDirection(degrees), "degrees: %v", degrees)
+	}
+}
@@ -0,0 +1,161 @@
+package weatherapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+	ErrInvalidKey       = errors.New("API key is missing or invalid")
+	ErrQuotaExceeded    = errors.New("API key has exceeded its monthly quota")
+	ErrAccessDenied     = errors.New("API key is disabled or has no access to the resource")
+	ErrBadRequest       = errors.New("invalid request")
+	ErrUnavailable      = errors.New("weather API is temporarily unavailable")
+)
+
+// WeatherAPI error codes, see https://www.weatherapi.com/docs/#intro-error-codes.
+const (
+	codeKeyNotProvided   = 1002
+	codeQueryNotProvided = 1003
+	codeInvalidURL       = 1005
+	codeLocationNotFound = 1006
+	codeInvalidKey       = 2006
+	codeQuotaExceeded    = 2007
+	codeKeyDisabled      = 2008
+	codeNoAccess         = 2009
+	codeInvalidBody      = 9000
+	codeTooManyLocations = 9001
+	codeInternalError    = 9999
+)
+
+// LocationError reports a query that matched no location. Suggestions
+// lists the places the query may have meant, such as "Paris, France".
+type LocationError struct {
+	Query       string
+	Suggestions []string
+}
+
+func (e *LocationError) Error() string {
+	return fmt.Sprintf("location %q not found", e.Query)
+}
+
+func (e *LocationError) Unwrap() error {
+	return ErrLocationNotFound
+}
+
+// StatusError reports a non-200 response from a weather API. Code and
+// Message come from the provider's error body when it has one. The error
+// unwraps to one of the Err values above so that callers can use errors.Is.
+type StatusError struct {
+	API        string
+	StatusCode int
+	Code       int64
+	Message    string
+	// RetryAfter is how long the provider asked to wait before the next
+	// request, from the Retry-After header of a 429 or 503 response.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s API not available. Code: %d", e.API, e.StatusCode)
+	if e.Code != 0 {
+		msg += fmt.Sprintf(" (%d: %s)", e.Code, e.Message)
+	}
+
+	return msg
+}
+
+func (e *StatusError) Unwrap() error {
+	switch e.Code {
+	case codeLocationNotFound:
+		return ErrLocationNotFound
+	case codeKeyNotProvided, codeInvalidKey:
+		return ErrInvalidKey
+	case codeQuotaExceeded:
+		return ErrQuotaExceeded
+	case codeKeyDisabled, codeNoAccess:
+		return ErrAccessDenied
+	case codeQueryNotProvided, codeInvalidURL, codeInvalidBody, codeTooManyLocations:
+		return ErrBadRequest
+	case codeInternalError:
+		return ErrUnavailable
+	}
+
+	switch {
+	case e.StatusCode >= http.StatusInternalServerError,
+		e.StatusCode == http.StatusTooManyRequests:
+		return ErrUnavailable
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrInvalidKey
+	case e.StatusCode == http.StatusForbidden:
+		return ErrAccessDenied
+	default:
+		return nil
+	}
+}
+
+// newStatusError parses a WeatherAPI error body such as
+// {"error":{"code":1006,"message":"No matching location found."}}.
+func newStatusError(statusCode int, body []byte) *StatusError {
+	var data struct {
+		Error struct {
+			Code    int64  `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	// A body that is not JSON leaves the code unset.
+	_ = json.Unmarshal(body, &data)
+
+	return &StatusError{
+		API:        "weather",
+		StatusCode: statusCode,
+		Code:       data.Error.Code,
+		Message:    data.Error.Message,
+	}
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date. A missing or malformed header is zero.
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// ProviderUnavailable reports whether err means the provider could not
+// answer at all, rather than that the request was wrong: outages, timeouts,
+// problems with the API key, a used-up call budget and operations the
+// provider does not support. Another provider, or an answer worked out
+// offline, may do better.
+func ProviderUnavailable(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, ErrUnavailable) ||
+		errors.Is(err, ErrInvalidKey) ||
+		errors.Is(err, ErrQuotaExceeded) ||
+		errors.Is(err, ErrAccessDenied) ||
+		errors.Is(err, ErrBudgetLow) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, errors.ErrUnsupported)
+}
@@ -0,0 +1,219 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		statusCode int
+		body       string
+		errString  string
+		wait       error
+	}{
+		"location_not_found": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"code":1006,"message":"No matching location found."}}`,
+			errString:  "weather API not available. Code: 400 (1006: No matching location found.)",
+			wait:       ErrLocationNotFound,
+		},
+		"invalid_key": {
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":{"code":2006,"message":"API key provided is invalid"}}`,
+			errString:  "weather API not available. Code: 401 (2006: API key provided is invalid)",
+			wait:       ErrInvalidKey,
+		},
+		"quota_exceeded": {
+			statusCode: http.StatusForbidden,
+			body:       `{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`,
+			errString:  "weather API not available. Code: 403 (2007: API key has exceeded calls per month quota.)",
+			wait:       ErrQuotaExceeded,
+		},
+		"internal_error": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"code":9999,"message":"Internal application error."}}`,
+			errString:  "weather API not available. Code: 400 (9999: Internal application error.)",
+			wait:       ErrUnavailable,
+		},
+		"rate_limited": {
+			statusCode: http.StatusTooManyRequests,
+			errString:  "weather API not available. Code: 429",
+			wait:       ErrUnavailable,
+		},
+		"body_is_not_json": {
+			statusCode: http.StatusBadGateway,
+			body:       "<html>Bad Gateway</html>",
+			errString:  "weather API not available. Code: 502",
+			wait:       ErrUnavailable,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := newStatusError(tc.statusCode, []byte(tc.body))
+
+			assert.EqualError(t, err, tc.errString)
+			assert.ErrorIs(t, err, tc.wait)
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		failures   int64
+		statusCode int
+		body       string
+		retryAfter string
+		requests   int64
+		minElapsed time.Duration
+		wait       error
+	}{
+		"recovers_after_outage": {
+			failures:   2,
+			statusCode: http.StatusServiceUnavailable,
+			requests:   3,
+		},
+		"gives_up_after_attempts": {
+			failures:   5,
+			statusCode: http.StatusInternalServerError,
+			requests:   3,
+			wait:       ErrUnavailable,
+		},
+		"rate_limit_waits_retry_after": {
+			failures:   1,
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: "1",
+			requests:   2,
+			minElapsed: time.Second,
+		},
+		"long_retry_after_is_not_waited": {
+			failures:   5,
+			statusCode: http.StatusTooManyRequests,
+			retryAfter: "3600",
+			requests:   1,
+			wait:       ErrUnavailable,
+		},
+		"client_errors_are_not_retried": {
+			failures:   5,
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"code":1006,"message":"No matching location found."}}`,
+			requests:   1,
+			wait:       ErrLocationNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int64
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tc.failures {
+					if tc.retryAfter != "" {
+						w.Header().Set("Retry-After", tc.retryAfter)
+					}
+
+					w.WriteHeader(tc.statusCode)
+					w.Write([]byte(tc.body))
+					return
+				}
+
+				w.Write([]byte(`{"location":{"name":"London"}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			weatherAPI := New("key", time.Second, WithRetries(3, time.Millisecond))
+			weatherAPI.baseURL = server.URL
+
+			start := time.Now()
+
+			result, err := weatherAPI.Current(context.Background(), "London")
+			if tc.wait != nil {
+				assert.ErrorIs(t, err, tc.wait)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "London", result.Location.Name)
+			}
+
+			assert.Equal(t, tc.requests, requests.Load())
+			assert.GreaterOrEqual(t, time.Since(start), tc.minElapsed)
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.April, 11, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		header string
+		wait   time.Duration
+	}{
+		"seconds":   {header: "5", wait: 5 * time.Second},
+		"http_date": {header: "Fri, 11 Apr 2025 12:00:30 GMT", wait: 30 * time.Second},
+		"past_date": {header: "Fri, 11 Apr 2025 11:00:00 GMT", wait: 0},
+		"missing":   {header: "", wait: 0},
+		"malformed": {header: "soon", wait: 0},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, retryAfter(tc.header, now))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	base := 100 * time.Millisecond
+
+	for attempt, limit := range map[int]time.Duration{1: base, 2: 2 * base, 3: 4 * base} {
+		delay := backoff(base, attempt)
+
+		assert.GreaterOrEqual(t, delay, limit/2)
+		assert.LessOrEqual(t, delay, limit)
+	}
+}
+
+func TestProviderUnavailable(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err  error
+		wait bool
+	}{
+		"server_error":      {err: &StatusError{API: "test", StatusCode: 502}, wait: true},
+		"client_error":      {err: &StatusError{API: "test", StatusCode: 404}, wait: false},
+		"quota_exceeded":    {err: &StatusError{API: "weather", StatusCode: 403, Code: 2007}, wait: true},
+		"unknown_location":  {err: &StatusError{API: "weather", StatusCode: 400, Code: 1006}, wait: false},
+		"deadline_exceeded": {err: fmt.Errorf("get: %w", context.DeadlineExceeded), wait: true},
+		"unsupported":       {err: fmt.Errorf("alerts: %w", errors.ErrUnsupported), wait: true},
+		"budget_low":        {err: ErrBudgetLow, wait: true},
+		"other":             {err: errors.New("location not found"), wait: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wait, ProviderUnavailable(tc.err))
+		})
+	}
+}
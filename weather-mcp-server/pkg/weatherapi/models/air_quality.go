@@ -0,0 +1,14 @@
+package models
+
+// AirQuality holds pollutant concentrations in μg/m³ together with
+// the US EPA (1-6) and UK DEFRA (1-10) indices.
+type AirQuality struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM25         float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEPAIndex   int64   `json:"us-epa-index"`
+	GBDEFRAIndex int64   `json:"gb-defra-index"`
+}
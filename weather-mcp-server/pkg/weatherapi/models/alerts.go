@@ -0,0 +1,26 @@
+package models
+
+type Alert struct {
+	Headline    string `json:"headline"`
+	MsgType     string `json:"msgtype"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Category    string `json:"category"`
+	Certainty   string `json:"certainty"`
+	Event       string `json:"event"`
+	Note        string `json:"note"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Desc        string `json:"desc"`
+	Instruction string `json:"instruction"`
+}
+
+type Alerts struct {
+	Alert []Alert `json:"alert"`
+}
+
+type AlertsResponse struct {
+	Location Location `json:"location"`
+	Alerts   Alerts   `json:"alerts"`
+}
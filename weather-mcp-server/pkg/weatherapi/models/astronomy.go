@@ -0,0 +1,10 @@
+package models
+
+type Astronomy struct {
+	Astro Astro `json:"astro"`
+}
+
+type AstronomyResponse struct {
+	Location  Location  `json:"location"`
+	Astronomy Astronomy `json:"astronomy"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "slices"
+
 type Current struct {
 	TempC      float64   `json:"temp_c"`
 	TempF      float64   `json:"temp_f"`
@@ -14,9 +16,41 @@ type Current struct {
 	GustKph    float64   `json:"gust_kph"`
 	PressureMb float64   `json:"pressure_mb"`
 	Condition  Condition `json:"condition"`
+	// LastUpdatedEpoch is when the weather was observed, in Unix seconds,
+	// and LastUpdated the same in the location's local time.
+	LastUpdatedEpoch int64       `json:"last_updated_epoch,omitempty"`
+	LastUpdated      string      `json:"last_updated,omitempty"`
+	AirQuality       *AirQuality `json:"air_quality,omitempty"`
+	Consensus        *Consensus  `json:"consensus,omitempty"`
+	// Missing lists the measurements the provider did not report, by the
+	// Field names below. They are left at zero.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// The names of the measurements that can be missing from Current.
+const (
+	FieldWindKph    = "wind_kph"
+	FieldWindDir    = "wind_dir"
+	FieldGustKph    = "gust_kph"
+	FieldHumidity   = "humidity"
+	FieldPressureMb = "pressure_mb"
+	FieldVisibility = "vis_km"
+)
+
+// Has reports whether the provider reported the measurement field.
+func (c *Current) Has(field string) bool {
+	return !slices.Contains(c.Missing, field)
 }
 
 type CurrentResponse struct {
 	Location Location `json:"location"`
 	Current  Current  `json:"current"`
 }
+
+// Consensus describes how closely several providers agreed on the current
+// weather when it was combined from all of them.
+type Consensus struct {
+	Providers      int     `json:"providers"`
+	TempSpreadC    float64 `json:"temp_spread_c"`
+	ConditionVotes int     `json:"condition_votes"`
+}
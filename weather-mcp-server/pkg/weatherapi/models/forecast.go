@@ -0,0 +1,82 @@
+package models
+
+type Day struct {
+	MaxtempC      float64   `json:"maxtemp_c"`
+	MaxtempF      float64   `json:"maxtemp_f"`
+	MintempC      float64   `json:"mintemp_c"`
+	MintempF      float64   `json:"mintemp_f"`
+	AvgtempC      float64   `json:"avgtemp_c"`
+	AvgtempF      float64   `json:"avgtemp_f"`
+	MaxwindKph    float64   `json:"maxwind_kph"`
+	MaxwindMph    float64   `json:"maxwind_mph"`
+	TotalprecipMm float64   `json:"totalprecip_mm"`
+	TotalprecipIn float64   `json:"totalprecip_in"`
+	TotalsnowCm   float64   `json:"totalsnow_cm"`
+	AvgVisibility float64   `json:"avgvis_km"`
+	AvgHumidity   int64     `json:"avghumidity"`
+	WillItRain    int64     `json:"daily_will_it_rain"`
+	ChanceOfRain  int64     `json:"daily_chance_of_rain"`
+	WillItSnow    int64     `json:"daily_will_it_snow"`
+	ChanceOfSnow  int64     `json:"daily_chance_of_snow"`
+	UV            float64   `json:"uv"`
+	Condition     Condition `json:"condition"`
+}
+
+type Astro struct {
+	Sunrise          string `json:"sunrise"`
+	Sunset           string `json:"sunset"`
+	Moonrise         string `json:"moonrise"`
+	Moonset          string `json:"moonset"`
+	MoonPhase        string `json:"moon_phase"`
+	MoonIllumination int64  `json:"moon_illumination"`
+	IsMoonUp         int64  `json:"is_moon_up"`
+	IsSunUp          int64  `json:"is_sun_up"`
+}
+
+type Hour struct {
+	TimeEpoch    int64     `json:"time_epoch"`
+	Time         string    `json:"time"`
+	TempC        float64   `json:"temp_c"`
+	TempF        float64   `json:"temp_f"`
+	IsDay        int64     `json:"is_day"`
+	WindKph      float64   `json:"wind_kph"`
+	WindMph      float64   `json:"wind_mph"`
+	WindDegree   int64     `json:"wind_degree"`
+	WindDir      string    `json:"wind_dir"`
+	PressureMb   float64   `json:"pressure_mb"`
+	PrecipMm     float64   `json:"precip_mm"`
+	PrecipIn     float64   `json:"precip_in"`
+	SnowCm       float64   `json:"snow_cm"`
+	Humidity     int64     `json:"humidity"`
+	Cloud        int64     `json:"cloud"`
+	FeelslikeC   float64   `json:"feelslike_c"`
+	FeelslikeF   float64   `json:"feelslike_f"`
+	DewpointC    float64   `json:"dewpoint_c"`
+	WillItRain   int64     `json:"will_it_rain"`
+	ChanceOfRain int64     `json:"chance_of_rain"`
+	WillItSnow   int64     `json:"will_it_snow"`
+	ChanceOfSnow int64     `json:"chance_of_snow"`
+	Visibility   float64   `json:"vis_km"`
+	GustKph      float64   `json:"gust_kph"`
+	GustMph      float64   `json:"gust_mph"`
+	UV           float64   `json:"uv"`
+	Condition    Condition `json:"condition"`
+}
+
+type ForecastDay struct {
+	Date      string `json:"date"`
+	DateEpoch int64  `json:"date_epoch"`
+	Day       Day    `json:"day"`
+	Astro     Astro  `json:"astro"`
+	Hour      []Hour `json:"hour"`
+}
+
+type Forecast struct {
+	ForecastDay []ForecastDay `json:"forecastday"`
+}
+
+type ForecastResponse struct {
+	Location Location `json:"location"`
+	Current  Current  `json:"current"`
+	Forecast Forecast `json:"forecast"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type HistoryResponse struct {
+	Location Location `json:"location"`
+	Forecast Forecast `json:"forecast"`
+}
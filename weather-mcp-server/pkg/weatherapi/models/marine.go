@@ -0,0 +1,58 @@
+package models
+
+type MarineHour struct {
+	TimeEpoch       int64     `json:"time_epoch"`
+	Time            string    `json:"time"`
+	TempC           float64   `json:"temp_c"`
+	IsDay           int64     `json:"is_day"`
+	WindKph         float64   `json:"wind_kph"`
+	WindDegree      int64     `json:"wind_degree"`
+	WindDir         string    `json:"wind_dir"`
+	GustKph         float64   `json:"gust_kph"`
+	Visibility      float64   `json:"vis_km"`
+	SigHtMt         float64   `json:"sig_ht_mt"`
+	SwellHtMt       float64   `json:"swell_ht_mt"`
+	SwellDir        float64   `json:"swell_dir"`
+	SwellDir16Point string    `json:"swell_dir_16_point"`
+	SwellPeriodSecs float64   `json:"swell_period_secs"`
+	WaterTempC      float64   `json:"water_temp_c"`
+	WaterTempF      float64   `json:"water_temp_f"`
+	Condition       Condition `json:"condition"`
+}
+
+// Tide is a high or low water. TideType is "HIGH" or "LOW", and the
+// height in metres is sent as a string.
+type Tide struct {
+	TideTime     string `json:"tide_time"`
+	TideHeightMt string `json:"tide_height_mt"`
+	TideType     string `json:"tide_type"`
+}
+
+type Tides struct {
+	Tide []Tide `json:"tide"`
+}
+
+type MarineDay struct {
+	MaxtempC   float64   `json:"maxtemp_c"`
+	MintempC   float64   `json:"mintemp_c"`
+	MaxwindKph float64   `json:"maxwind_kph"`
+	Condition  Condition `json:"condition"`
+	Tides      []Tides   `json:"tides"`
+}
+
+type MarineForecastDay struct {
+	Date      string       `json:"date"`
+	DateEpoch int64        `json:"date_epoch"`
+	Day       MarineDay    `json:"day"`
+	Astro     Astro        `json:"astro"`
+	Hour      []MarineHour `json:"hour"`
+}
+
+type MarineForecast struct {
+	ForecastDay []MarineForecastDay `json:"forecastday"`
+}
+
+type MarineResponse struct {
+	Location Location       `json:"location"`
+	Forecast MarineForecast `json:"forecast"`
+}
@@ -1,11 +1,20 @@
 package models
 
 type Location struct {
-	Name    string `json:"name"`
-	Country string `json:"country"`
+	Name    string  `json:"name"`
+	Region  string  `json:"region"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	TzID    string  `json:"tz_id,omitempty"`
+	// Localtime is the wall clock at the location when the response was
+	// made, such as "2025-04-11 13:07".
+	Localtime      string `json:"localtime,omitempty"`
+	LocaltimeEpoch int64  `json:"localtime_epoch,omitempty"`
 }
 
 type Condition struct {
 	Text string `json:"text"`
 	Icon string `json:"icon"`
+	Code int64  `json:"code"`
 }
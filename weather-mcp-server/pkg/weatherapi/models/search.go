@@ -0,0 +1,15 @@
+package models
+
+// Place is a location matching a search. ID is stable and can be queried
+// as "id:<ID>" to get the weather for exactly this place.
+type Place struct {
+	ID      int64   `json:"id"`
+	Name    string  `json:"name"`
+	Region  string  `json:"region"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	URL     string  `json:"url"`
+}
+
+type SearchResponse []Place
@@ -0,0 +1,95 @@
+package weatherapi
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	locationIDPrefix = "id:"
+	iataPrefix       = "iata:"
+	metarPrefix      = "metar:"
+	autoIPQuery      = "auto:ip"
+)
+
+// ParseCoordinates parses a "lat,lon" query.
+func ParseCoordinates(query string) (lat, lon float64, ok bool) {
+	latText, lonText, found := strings.Cut(query, ",")
+	if !found {
+		return 0, 0, false
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, false
+	}
+
+	lon, err = strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+
+	return lat, lon, true
+}
+
+// LocationQuery returns the query for the place with the given search id.
+func LocationQuery(id int64) string {
+	return locationIDPrefix + strconv.FormatInt(id, 10)
+}
+
+// IsLocationID reports whether query names a place by its search id,
+// whether or not the id is well formed.
+func IsLocationID(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), locationIDPrefix)
+}
+
+// ParseLocationID parses an "id:<id>" query.
+func ParseLocationID(query string) (int64, bool) {
+	text, found := strings.CutPrefix(strings.TrimSpace(query), locationIDPrefix)
+	if !found {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// AirportQuery returns the query for an airport given its 3-letter IATA
+// code or its 4-letter ICAO code, which is looked up as a METAR station.
+func AirportQuery(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) == 4 {
+		return metarPrefix + code
+	}
+
+	return iataPrefix + code
+}
+
+// IPQuery returns the query for the location of an IP address, or of the
+// caller's own address when ip is empty.
+func IPQuery(ip string) string {
+	if ip == "" {
+		return autoIPQuery
+	}
+
+	return ip
+}
+
+// IsPrefixedQuery reports whether query uses one of the WeatherAPI forms
+// that other providers cannot resolve: a search id, an airport code or
+// the caller's IP address.
+func IsPrefixedQuery(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	for _, prefix := range []string{locationIDPrefix, iataPrefix, metarPrefix, autoIPQuery} {
+		if strings.HasPrefix(query, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package weatherapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		query    string
+		lat, lon float64
+		ok       bool
+	}{
+		"coordinates":        {query: "51.5171,-0.1062", lat: 51.5171, lon: -0.1062, ok: true},
+		"with_spaces":        {query: "40.71, -74.01", lat: 40.71, lon: -74.01, ok: true},
+		"city_name":          {query: "London"},
+		"city_with_country":  {query: "Paris, France"},
+		"latitude_too_large": {query: "91,0"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			lat, lon, ok := ParseCoordinates(tc.query)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.lat, lat)
+			assert.Equal(t, tc.lon, lon)
+		})
+	}
+}
+
+func TestParseLocationID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		query string
+		id    int64
+		ok    bool
+	}{
+		"location_id":  {query: "id:2618724", id: 2618724, ok: true},
+		"with_spaces":  {query: " id:2618724 ", id: 2618724, ok: true},
+		"city_name":    {query: "Springfield"},
+		"missing_id":   {query: "id:"},
+		"not_a_number": {query: "id:springfield"},
+		"negative":     {query: "id:-5"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			id, ok := ParseLocationID(tc.query)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+
+	assert.Equal(t, "id:2618724", LocationQuery(2618724))
+}
+
+func TestLocationQueries(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "iata:LHR", AirportQuery("lhr"))
+	assert.Equal(t, "metar:EGLL", AirportQuery("EGLL"))
+	assert.Equal(t, "auto:ip", IPQuery(""))
+	assert.Equal(t, "100.0.0.1", IPQuery("100.0.0.1"))
+
+	for query, prefixed := range map[string]bool{
+		"id:2801268":  true,
+		"iata:LHR":    true,
+		"metar:EGLL":  true,
+		"auto:ip":     true,
+		"London":      false,
+		"SW1A 1AA":    false,
+		"51.5,-0.12":  false,
+		"Idaho Falls": false,
+	} {
+		assert.Equal(t, prefixed, IsPrefixedQuery(query), query)
+	}
+}
@@ -3,44 +3,293 @@ package weatherapi
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
 )
 
-const baseURL = "http://api.weatherapi.com"
+const (
+	baseURL    = "http://api.weatherapi.com"
+	dateLayout = "2006-01-02"
+)
+
+const (
+	defaultAttempts   = 3
+	defaultRetryDelay = 250 * time.Millisecond
+
+	// maxRetryAfter is the longest Retry-After a request waits out before
+	// retrying. Longer waits fail at once, so that another provider or the
+	// cache can answer instead.
+	maxRetryAfter = 10 * time.Second
+)
 
 type WeatherAPI struct {
-	key     string
-	baseURL string
-	client  *http.Client
+	key        string
+	baseURL    string
+	client     *http.Client
+	limiter    *rate.Limiter
+	budget     *Budget
+	attempts   int
+	retryDelay time.Duration
+}
+
+// ClientOption configures the client.
+type ClientOption func(w *WeatherAPI)
+
+// WithRateLimit paces requests to perSecond on average, allowing bursts
+// of up to burst requests.
+func WithRateLimit(perSecond float64, burst int) ClientOption {
+	return func(w *WeatherAPI) {
+		w.limiter = rate.NewLimiter(rate.Limit(perSecond), burst)
+	}
 }
 
-func New(key string, timeout time.Duration) *WeatherAPI {
-	return &WeatherAPI{
+// WithRetries makes up to attempts requests when the provider is
+// unavailable, rate limits the request or the network fails, waiting about
+// baseDelay before the first retry and twice as long before each next one,
+// or as long as the provider's Retry-After asks. By default requests
+// are made 3 times starting at 250ms.
+func WithRetries(attempts int, baseDelay time.Duration) ClientOption {
+	return func(w *WeatherAPI) {
+		w.attempts = max(attempts, 1)
+		w.retryDelay = baseDelay
+	}
+}
+
+// WithBudget counts every request against budget, once however many
+// times it is retried, and refuses requests with ErrBudgetLow once it runs
+// low.
+func WithBudget(budget *Budget) ClientOption {
+	return func(w *WeatherAPI) {
+		w.budget = budget
+	}
+}
+
+func New(key string, timeout time.Duration, opts ...ClientOption) *WeatherAPI {
+	w := &WeatherAPI{
 		key:     key,
 		baseURL: baseURL,
 		client: &http.Client{
 			Timeout: timeout,
 		},
+		attempts:   defaultAttempts,
+		retryDelay: defaultRetryDelay,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Option adds optional parameters to a request query.
+type Option func(query url.Values)
+
+// WithAirQuality requests air quality data along with the weather.
+func WithAirQuality() Option {
+	return func(query url.Values) {
+		query.Set("aqi", "yes")
+	}
+}
+
+// WithAlerts requests government weather alerts along with the forecast.
+func WithAlerts() Option {
+	return func(query url.Values) {
+		query.Set("alerts", "yes")
+	}
+}
+
+// WithLanguage requests the condition text in the given language.
+func WithLanguage(lang string) Option {
+	return func(query url.Values) {
+		query.Set("lang", lang)
+	}
+}
+
+func (w *WeatherAPI) Current(ctx context.Context, city string, opts ...Option) (*models.CurrentResponse, error) {
+	query := url.Values{
+		"q": {city},
+	}
+
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	var data models.CurrentResponse
+
+	if err := w.get(ctx, "/v1/current.json", query, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (w *WeatherAPI) Forecast(ctx context.Context, city string, days int, opts ...Option) (*models.ForecastResponse, error) {
+	query := url.Values{
+		"q":    {city},
+		"days": {strconv.Itoa(days)},
+	}
+
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	var data models.ForecastResponse
+
+	if err := w.get(ctx, "/v1/forecast.json", query, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// Alerts returns the active weather alerts, which the API only serves
+// through the forecast endpoint.
+func (w *WeatherAPI) Alerts(ctx context.Context, city string) (*models.AlertsResponse, error) {
+	query := url.Values{
+		"q":    {city},
+		"days": {"1"},
+	}
+
+	WithAlerts()(query)
+
+	var data models.AlertsResponse
+
+	if err := w.get(ctx, "/v1/forecast.json", query, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// Marine returns the marine forecast for a coastal or sea location,
+// including tides, for up to 7 days.
+func (w *WeatherAPI) Marine(ctx context.Context, query string, days int) (*models.MarineResponse, error) {
+	values := url.Values{
+		"q":     {query},
+		"days":  {strconv.Itoa(days)},
+		"tides": {"yes"},
+	}
+
+	var data models.MarineResponse
+
+	if err := w.get(ctx, "/v1/marine.json", values, &data); err != nil {
+		return nil, err
 	}
+
+	return &data, nil
+}
+
+// History returns observed daily weather from date through endDate.
+// A zero endDate requests the single day.
+func (w *WeatherAPI) History(ctx context.Context, city string, date, endDate time.Time) (*models.HistoryResponse, error) {
+	query := url.Values{
+		"q":  {city},
+		"dt": {date.Format(dateLayout)},
+	}
+
+	if !endDate.IsZero() {
+		if endDate.Before(date) {
+			return nil, errors.New("end date must not be before the start date")
+		}
+
+		query.Set("end_dt", endDate.Format(dateLayout))
+	}
+
+	var data models.HistoryResponse
+
+	if err := w.get(ctx, "/v1/history.json", query, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
 }
 
-func (w *WeatherAPI) Current(ctx context.Context, city string) (*models.CurrentResponse, error) {
+// Astronomy returns the sun and moon times, moon phase and illumination
+// at the location on date, in the location's local time.
+func (w *WeatherAPI) Astronomy(ctx context.Context, city string, date time.Time) (*models.AstronomyResponse, error) {
 	query := url.Values{
-		"key": {w.key},
-		"q":   {city},
+		"q":  {city},
+		"dt": {date.Format(dateLayout)},
+	}
+
+	var data models.AstronomyResponse
+
+	if err := w.get(ctx, "/v1/astronomy.json", query, &data); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// Search returns the places matching query, so that a caller can tell
+// apart places that share a name.
+func (w *WeatherAPI) Search(ctx context.Context, query string) (models.SearchResponse, error) {
+	var data models.SearchResponse
+
+	if err := w.get(ctx, "/v1/search.json", url.Values{"q": {query}}, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (w *WeatherAPI) get(ctx context.Context, path string, query url.Values, data any) error {
+	query.Set("key", w.key)
+
+	endpoint := w.baseURL + path + "?" + query.Encode()
+
+	// A request counts once against the budget however many attempts it
+	// takes, since it is one question from the caller.
+	if w.budget != nil {
+		if err := w.budget.Spend(); err != nil {
+			return err
+		}
 	}
 
-	request, err := http.NewRequestWithContext(ctx,
-		http.MethodGet,
-		w.baseURL+"/v1/current.json?"+query.Encode(),
-		nil,
-	)
+	var err error
+
+	for attempt := range max(w.attempts, 1) {
+		if attempt > 0 {
+			if err := sleep(ctx, retryDelay(err, w.retryDelay, attempt)); err != nil {
+				return err
+			}
+		}
+
+		var body []byte
+
+		body, err = w.do(ctx, endpoint)
+		if err == nil {
+			return json.Unmarshal(body, data)
+		}
+
+		if !transient(ctx, err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// do makes one request, paced by the rate limiter.
+func (w *WeatherAPI) do(ctx context.Context, endpoint string) ([]byte, error) {
+	if w.limiter != nil {
+		if err := w.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,20 +300,76 @@ func (w *WeatherAPI) Current(ctx context.Context, city string) (*models.CurrentR
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("weather API not available. Code: %d", response.StatusCode)
-	}
-
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	var data models.CurrentResponse
+	if response.StatusCode != http.StatusOK {
+		statusErr := newStatusError(response.StatusCode, body)
+		statusErr.RetryAfter = retryAfter(response.Header.Get("Retry-After"), time.Now())
 
-	if err = json.Unmarshal(body, &data); err != nil {
-		return nil, err
+		if w.budget != nil && errors.Is(statusErr, ErrQuotaExceeded) {
+			w.budget.Exhaust()
+		}
+
+		return nil, statusErr
 	}
 
-	return &data, nil
+	return body, nil
+}
+
+// transient reports whether a failed request may succeed when retried:
+// provider outages, rate limiting and network errors, unless the caller
+// has given up or the provider asked to wait longer than maxRetryAfter.
+func transient(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return errors.Is(err, ErrUnavailable) && statusErr.RetryAfter <= maxRetryAfter
+	}
+
+	if errors.Is(err, ErrUnavailable) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// retryDelay is the wait before attempt: the backoff, or longer if the
+// failed request's Retry-After header asked for it.
+func retryDelay(err error, base time.Duration, attempt int) time.Duration {
+	delay := backoff(base, attempt)
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		delay = max(delay, statusErr.RetryAfter)
+	}
+
+	return delay
+}
+
+// backoff doubles the delay with every attempt and picks a random point in
+// its upper half, so that clients retrying together spread out.
+func backoff(base time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
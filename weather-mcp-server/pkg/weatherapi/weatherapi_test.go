@@ -7,12 +7,44 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/TuanKiri/weather-mcp-server/pkg/weatherapi/models"
 )
 
+// newTestServer serves the mock/<endpoint>.json fixture that matches the request path.
+func newTestServer(t *testing.T) *WeatherAPI {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		path := filepath.Join("mock", filepath.Base(r.URL.Path))
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return &WeatherAPI{
+		key:     "test-key",
+		baseURL: server.URL,
+		client:  server.Client(),
+	}
+}
+
 func TestCurrentWeather(t *testing.T) {
 	t.Parallel()
 
@@ -25,16 +57,120 @@ func TestCurrentWeather(t *testing.T) {
 			city: "London",
 			wait: &models.CurrentResponse{
 				Location: models.Location{
-					Name:    "London",
-					Country: "United Kingdom",
+					Name:           "London",
+					Region:         "City of London, Greater London",
+					Country:        "United Kingdom",
+					Lat:            51.5171,
+					Lon:            -0.1062,
+					TzID:           "Europe/London",
+					Localtime:      "2025-04-11 13:07",
+					LocaltimeEpoch: 1744373247,
 				},
 				Current: models.Current{
-					TempC:    18.4,
-					WindKph:  4,
-					Humidity: 45,
+					TempC:      18.4,
+					TempF:      65.1,
+					WindKph:    4,
+					WindMph:    2.5,
+					WindDir:    "WSW",
+					Humidity:   45,
+					FeelslikeC: 18.4,
+					FeelslikeF: 65.1,
+					Visibility: 10,
+					UV:         4.2,
+					GustKph:    4.6,
+					PressureMb: 1022,
+					Condition: models.Condition{
+						Text: "Sunny",
+						Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+						Code: 1000,
+					},
+					LastUpdatedEpoch: 1744372800,
+					LastUpdated:      "2025-04-11 13:00",
+				},
+			},
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.Current(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestForecast(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		city      string
+		errString string
+		wait      *models.ForecastDay
+	}{
+		"successful_request": {
+			city: "London",
+			wait: &models.ForecastDay{
+				Date:      "2025-04-11",
+				DateEpoch: 1744329600,
+				Day: models.Day{
+					MaxtempC:      19.6,
+					MaxtempF:      67.3,
+					MintempC:      7.2,
+					MintempF:      45,
+					AvgtempC:      13.1,
+					AvgtempF:      55.6,
+					MaxwindKph:    13.7,
+					MaxwindMph:    8.5,
+					AvgVisibility: 10,
+					AvgHumidity:   58,
+					UV:            1.6,
 					Condition: models.Condition{
 						Text: "Sunny",
 						Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+						Code: 1000,
+					},
+				},
+				Astro: models.Astro{
+					Sunrise:          "06:14 AM",
+					Sunset:           "07:55 PM",
+					Moonrise:         "05:34 PM",
+					Moonset:          "05:43 AM",
+					MoonPhase:        "Waxing Gibbous",
+					MoonIllumination: 95,
+				},
+				Hour: []models.Hour{
+					{
+						TimeEpoch:  1744326000,
+						Time:       "2025-04-11 00:00",
+						TempC:      9.1,
+						TempF:      48.4,
+						WindKph:    8.6,
+						WindMph:    5.4,
+						WindDegree: 62,
+						WindDir:    "ENE",
+						PressureMb: 1025,
+						Humidity:   77,
+						Cloud:      4,
+						FeelslikeC: 7.5,
+						FeelslikeF: 45.5,
+						DewpointC:  5.3,
+						Visibility: 10,
+						GustKph:    15.5,
+						GustMph:    9.6,
+						Condition: models.Condition{
+							Text: "Clear ",
+							Icon: "//cdn.weatherapi.com/weather/64x64/night/113.png",
+							Code: 1000,
+						},
 					},
 				},
 			},
@@ -44,16 +180,36 @@ func TestCurrentWeather(t *testing.T) {
 		},
 	}
 
+	weatherAPI := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.Forecast(context.Background(), tc.city, 1)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.Equal(t, "London", result.Location.Name)
+			assert.Equal(t, 18.4, result.Current.TempC)
+			if assert.Len(t, result.Forecast.ForecastDay, 1) {
+				assert.Equal(t, *tc.wait, result.Forecast.ForecastDay[0])
+			}
+		})
+	}
+}
+
+func TestCurrentWeatherAirQuality(t *testing.T) {
+	t.Parallel()
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		q := r.URL.Query().Get("q")
-		if q == "" {
+		if r.URL.Query().Get("aqi") != "yes" {
 			http.Error(w, "", http.StatusBadRequest)
 			return
 		}
 
-		path := filepath.Join("mock", "current.json")
-
-		data, err := os.ReadFile(path)
+		data, err := os.ReadFile(filepath.Join("mock", "air_quality.json"))
 		if err != nil {
 			http.Error(w, "", http.StatusInternalServerError)
 			return
@@ -70,9 +226,356 @@ func TestCurrentWeather(t *testing.T) {
 		client:  server.Client(),
 	}
 
+	result, err := weatherAPI.Current(context.Background(), "London", WithAirQuality())
+	assert.NoError(t, err)
+
+	assert.Equal(t, &models.AirQuality{
+		CO:           227.9,
+		NO2:          11.47,
+		O3:           92,
+		SO2:          2.035,
+		PM25:         5.2,
+		PM10:         8.325,
+		USEPAIndex:   1,
+		GBDEFRAIndex: 1,
+	}, result.Current.AirQuality)
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		city      string
+		errString string
+		wait      *models.AlertsResponse
+	}{
+		"successful_request": {
+			city: "London",
+			wait: &models.AlertsResponse{
+				Location: models.Location{
+					Name:           "London",
+					Region:         "City of London, Greater London",
+					Country:        "United Kingdom",
+					Lat:            51.5171,
+					Lon:            -0.1062,
+					TzID:           "Europe/London",
+					Localtime:      "2025-04-11 13:07",
+					LocaltimeEpoch: 1744373247,
+				},
+				Alerts: models.Alerts{
+					Alert: []models.Alert{
+						{
+							Headline:    "Met Office: Yellow warning for rain",
+							MsgType:     "Alert",
+							Severity:    "Moderate",
+							Urgency:     "Expected",
+							Areas:       "London & South East England",
+							Category:    "Met",
+							Certainty:   "Likely",
+							Event:       "Yellow warning for rain",
+							Effective:   "2025-04-11T09:00:00+00:00",
+							Expires:     "2025-04-11T21:00:00+00:00",
+							Desc:        "Heavy rain may lead to some travel disruption.",
+							Instruction: "Allow extra time for journeys.",
+						},
+					},
+				},
+			},
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			result, err := weatherAPI.Current(context.Background(), tc.city)
+			result, err := weatherAPI.Alerts(context.Background(), tc.city)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		query     string
+		errString string
+		wait      models.SearchResponse
+	}{
+		"successful_request": {
+			query: "Springfield",
+			wait: models.SearchResponse{
+				{
+					ID:      2618724,
+					Name:    "Springfield",
+					Region:  "Illinois",
+					Country: "United States of America",
+					Lat:     39.8,
+					Lon:     -89.64,
+					URL:     "springfield-illinois-united-states-of-america",
+				},
+				{
+					ID:      2619102,
+					Name:    "Springfield",
+					Region:  "Missouri",
+					Country: "United States of America",
+					Lat:     37.22,
+					Lon:     -93.3,
+					URL:     "springfield-missouri-united-states-of-america",
+				},
+				{
+					ID:      2616794,
+					Name:    "Springfield",
+					Region:  "Massachusetts",
+					Country: "United States of America",
+					Lat:     42.1,
+					Lon:     -72.59,
+					URL:     "springfield-massachusetts-united-states-of-america",
+				},
+			},
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.Search(context.Background(), tc.query)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		city      string
+		endDate   time.Time
+		errString string
+		wait      []string
+	}{
+		"successful_request": {
+			city:    "London",
+			endDate: date.AddDate(0, 0, 1),
+			wait:    []string{"2025-04-09", "2025-04-10"},
+		},
+		"end_date_before_start": {
+			city:      "London",
+			endDate:   date.AddDate(0, 0, -1),
+			errString: "end date must not be before the start date",
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.History(context.Background(), tc.city, date, tc.endDate)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+				assert.Nil(t, result)
+				return
+			}
+
+			var dates []string
+			for _, day := range result.Forecast.ForecastDay {
+				dates = append(dates, day.Date)
+			}
+
+			assert.Equal(t, "London", result.Location.Name)
+			assert.Equal(t, tc.wait, dates)
+			assert.Equal(t, 4.2, result.Forecast.ForecastDay[1].Day.TotalprecipMm)
+		})
+	}
+}
+
+func TestAstronomy(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		city      string
+		errString string
+		wait      *models.AstronomyResponse
+	}{
+		"successful_request": {
+			city: "London",
+			wait: &models.AstronomyResponse{
+				Location: models.Location{
+					Name:           "London",
+					Region:         "City of London, Greater London",
+					Country:        "United Kingdom",
+					Lat:            51.52,
+					Lon:            -0.11,
+					TzID:           "Europe/London",
+					Localtime:      "2025-06-21 11:00",
+					LocaltimeEpoch: 1750500000,
+				},
+				Astronomy: models.Astronomy{
+					Astro: models.Astro{
+						Sunrise:          "04:43 AM",
+						Sunset:           "09:21 PM",
+						Moonrise:         "01:47 AM",
+						Moonset:          "06:38 PM",
+						MoonPhase:        "Waning Crescent",
+						MoonIllumination: 20,
+						IsMoonUp:         1,
+						IsSunUp:          1,
+					},
+				},
+			},
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
+	date := time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.Astronomy(context.Background(), tc.city, date)
+			if err != nil {
+				assert.EqualError(t, err, tc.errString)
+			}
+
+			assert.Equal(t, tc.wait, result)
+		})
+	}
+}
+
+func TestMarine(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		query     string
+		errString string
+		wait      *models.MarineResponse
+	}{
+		"successful_request": {
+			query: "Brighton",
+			wait: &models.MarineResponse{
+				Location: models.Location{
+					Name:           "Brighton",
+					Region:         "East Sussex",
+					Country:        "United Kingdom",
+					Lat:            50.83,
+					Lon:            -0.15,
+					TzID:           "Europe/London",
+					Localtime:      "2025-06-21 11:00",
+					LocaltimeEpoch: 1750500000,
+				},
+				Forecast: models.MarineForecast{
+					ForecastDay: []models.MarineForecastDay{{
+						Date:      "2025-06-21",
+						DateEpoch: 1750464000,
+						Day: models.MarineDay{
+							MaxtempC:   19.5,
+							MintempC:   14.8,
+							MaxwindKph: 20.5,
+							Condition: models.Condition{
+								Text: "Sunny",
+								Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+								Code: 1000,
+							},
+							Tides: []models.Tides{{Tide: []models.Tide{
+								{TideTime: "2025-06-21 04:12", TideHeightMt: "5.82", TideType: "HIGH"},
+								{TideTime: "2025-06-21 10:31", TideHeightMt: "1.14", TideType: "LOW"},
+							}}},
+						},
+						Astro: models.Astro{
+							Sunrise:          "04:49 AM",
+							Sunset:           "09:13 PM",
+							Moonrise:         "01:58 AM",
+							Moonset:          "06:24 PM",
+							MoonPhase:        "Waning Crescent",
+							MoonIllumination: 20,
+							IsMoonUp:         1,
+							IsSunUp:          1,
+						},
+						Hour: []models.MarineHour{
+							{
+								TimeEpoch:       1750492800,
+								Time:            "2025-06-21 09:00",
+								TempC:           17.2,
+								IsDay:           1,
+								WindKph:         14.4,
+								WindDegree:      240,
+								WindDir:         "WSW",
+								GustKph:         19.8,
+								Visibility:      10,
+								SigHtMt:         0.6,
+								SwellHtMt:       0.4,
+								SwellDir:        235,
+								SwellDir16Point: "SW",
+								SwellPeriodSecs: 7.2,
+								WaterTempC:      16.4,
+								WaterTempF:      61.5,
+								Condition: models.Condition{
+									Text: "Sunny",
+									Icon: "//cdn.weatherapi.com/weather/64x64/day/113.png",
+									Code: 1000,
+								},
+							},
+							{
+								TimeEpoch:       1750496400,
+								Time:            "2025-06-21 10:00",
+								TempC:           17.2,
+								IsDay:           1,
+								WindKph:         20.5,
+								WindDegree:      250,
+								WindDir:         "WSW",
+								GustKph:         27.4,
+								Visibility:      10,
+								SigHtMt:         0.8,
+								SwellHtMt:       0.5,
+								SwellDir:        238,
+								SwellDir16Point: "WSW",
+								SwellPeriodSecs: 7.6,
+								WaterTempC:      16.5,
+								WaterTempF:      61.7,
+								Condition: models.Condition{
+									Text: "Partly cloudy",
+									Icon: "//cdn.weatherapi.com/weather/64x64/day/116.png",
+									Code: 1003,
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		"bad_request": {
+			errString: "weather API not available. Code: 400",
+		},
+	}
+
+	weatherAPI := newTestServer(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := weatherAPI.Marine(context.Background(), tc.query, 1)
 			if err != nil {
 				assert.EqualError(t, err, tc.errString)
 			}